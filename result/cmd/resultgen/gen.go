@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// spec describes a single function to wrap.
+type spec struct {
+	name  string
+	path  string
+	fn    string
+	input int
+}
+
+func parseSpec(s string) (spec, error) {
+	var sp spec
+	if name, rest, ok := strings.Cut(s, "="); ok {
+		sp.name, s = name, rest
+	}
+	if rest, idx, ok := strings.Cut(s, "@"); ok {
+		i, err := strconv.Atoi(idx)
+		if err != nil {
+			return spec{}, fmt.Errorf("invalid input index in %q: %w", s, err)
+		}
+		sp.input, s = i, rest
+	}
+	dot := strings.LastIndex(s, ".")
+	if dot <= strings.LastIndex(s, "/") {
+		return spec{}, fmt.Errorf("invalid function %q, want importpath.Func", s)
+	}
+	sp.path, sp.fn = s[:dot], s[dot+1:]
+	if sp.name == "" {
+		sp.name = sp.fn
+	}
+	return sp, nil
+}
+
+const (
+	resultPath = "github.com/kdungs/go-result/result"
+	thenPath   = "github.com/kdungs/go-result/then"
+)
+
+type generator struct {
+	imp     types.Importer
+	imports map[string]string // path -> name
+	body    bytes.Buffer
+	err     error
+}
+
+func generate(imp types.Importer, pkg string, specs []spec) ([]byte, error) {
+	g := &generator{
+		imp:     imp,
+		imports: map[string]string{resultPath: "result"},
+	}
+	for _, s := range specs {
+		if err := g.wrap(s); err != nil {
+			return nil, err
+		}
+	}
+	if g.err != nil {
+		return nil, g.err
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by resultgen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+	var std, other []string
+	for p := range g.imports {
+		if first, _, _ := strings.Cut(p, "/"); strings.Contains(first, ".") {
+			other = append(other, p)
+		} else {
+			std = append(std, p)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+	for _, p := range std {
+		fmt.Fprintf(&src, "\t%q\n", p)
+	}
+	src.WriteString("\n")
+	for _, p := range other {
+		fmt.Fprintf(&src, "\t%q\n", p)
+	}
+	src.WriteString(")\n")
+	src.Write(g.body.Bytes())
+	return format.Source(src.Bytes())
+}
+
+func (g *generator) qualify(p *types.Package) string {
+	for path, name := range g.imports {
+		if name == p.Name() && path != p.Path() && g.err == nil {
+			g.err = fmt.Errorf("packages %s and %s have the same name", path, p.Path())
+		}
+	}
+	g.imports[p.Path()] = p.Name()
+	return p.Name()
+}
+
+func (g *generator) wrap(s spec) error {
+	pkg, err := g.imp.Import(s.path)
+	if err != nil {
+		return err
+	}
+	obj, ok := pkg.Scope().Lookup(s.fn).(*types.Func)
+	if !ok || !obj.Exported() {
+		return fmt.Errorf("%s.%s is not an exported function", s.path, s.fn)
+	}
+	sig := obj.Type().(*types.Signature)
+	res := sig.Results()
+	if res.Len() != 2 || !types.Identical(res.At(1).Type(), types.Universe.Lookup("error").Type()) {
+		return fmt.Errorf("%s.%s does not return (T, error)", s.path, s.fn)
+	}
+	params := sig.Params()
+	if sig.Variadic() || sig.TypeParams().Len() > 0 {
+		return fmt.Errorf("%s.%s is variadic or generic", s.path, s.fn)
+	}
+	if s.input < 0 || s.input >= params.Len() {
+		return fmt.Errorf("%s.%s has no parameter %d", s.path, s.fn, s.input)
+	}
+
+	typ := func(t types.Type) string { return types.TypeString(t, g.qualify) }
+	call := fmt.Sprintf("%s.%s", g.qualify(pkg), s.fn)
+	names := make([]string, params.Len())
+	decls := make([]string, params.Len())
+	for i := range names {
+		p := params.At(i)
+		names[i] = p.Name()
+		if names[i] == "" || names[i] == "_" || token.IsKeyword(names[i]) {
+			names[i] = fmt.Sprintf("p%d", i)
+		}
+		decls[i] = names[i] + " " + typ(p.Type())
+	}
+	// Parameter names must not shadow imported packages.
+	for i, n := range names {
+		for _, pn := range g.imports {
+			if n == pn {
+				names[i] = fmt.Sprintf("p%d", i)
+				decls[i] = names[i] + " " + typ(params.At(i).Type())
+			}
+		}
+	}
+	in, out := typ(params.At(s.input).Type()), typ(res.At(0).Type())
+	args := strings.Join(names, ", ")
+
+	fmt.Fprintf(&g.body, "\n// %s is %s as a result function.\n", s.name, call)
+	if params.Len() == 1 {
+		// A plain function rather than a variable, so that importers cannot
+		// replace it.
+		fmt.Fprintf(&g.body, "func %s(%s) (%s, error) {\n\treturn %s(%s)\n}\n", s.name, decls[0], out, call, args)
+	} else {
+		g.imports[thenPath] = "then"
+		fnType := fmt.Sprintf("then.FN[%s, %s]", in, out)
+		rest := append(append([]string{}, decls[:s.input]...), decls[s.input+1:]...)
+		fmt.Fprintf(&g.body, "// The parameter %s is the input; all others are fixed by the arguments.\n", names[s.input])
+		fmt.Fprintf(&g.body, "func %s(%s) %s {\n", s.name, strings.Join(rest, ", "), fnType)
+		fmt.Fprintf(&g.body, "\treturn func(%s) (%s, error) {\n\t\treturn %s(%s)\n\t}\n}\n", decls[s.input], out, call, args)
+	}
+	fmt.Fprintf(&g.body, "\n// %sR is like %s but returns a result.R.\n", s.name, call)
+	fmt.Fprintf(&g.body, "func %sR(%s) result.R[%s] {\n\treturn result.Wrap(%s(%s))\n}\n", s.name, strings.Join(decls, ", "), out, call, args)
+	return nil
+}
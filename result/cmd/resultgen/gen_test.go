@@ -0,0 +1,92 @@
+package main
+
+import (
+	"go/importer"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestParseSpec(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected spec
+		err      bool
+	}{
+		{in: "strconv.Atoi", expected: spec{name: "Atoi", path: "strconv", fn: "Atoi"}},
+		{in: "ParseURL=net/url.Parse", expected: spec{name: "ParseURL", path: "net/url", fn: "Parse"}},
+		{in: "ParseTime=time.Parse@1", expected: spec{name: "ParseTime", path: "time", fn: "Parse", input: 1}},
+		{in: "example.com/pkg", err: true},
+		{in: "strconv.Atoi@x", err: true},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			s, err := parseSpec(tc.in)
+			if (err != nil) != tc.err {
+				t.Fatalf("got %v, want error: %v", err, tc.err)
+			}
+			if err == nil && s != tc.expected {
+				t.Fatalf("got %+v, want %+v", s, tc.expected)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	imp := importer.ForCompiler(token.NewFileSet(), "source", nil)
+	cases := []struct {
+		name       string
+		spec       string
+		expected   []string
+		unexpected []string
+		err        bool
+	}{
+		{
+			name: "single parameter",
+			spec: "strconv.Atoi",
+			expected: []string{
+				"func Atoi(s string) (int, error) {",
+				"return strconv.Atoi(s)",
+				"func AtoiR(s string) result.R[int] {",
+			},
+			unexpected: []string{"var Atoi", `"github.com/kdungs/go-result/then"`},
+		},
+		{
+			name: "input parameter",
+			spec: "ParseTime=time.Parse@1",
+			expected: []string{
+				"func ParseTime(layout string) then.FN[string, time.Time] {",
+				"return func(value string) (time.Time, error) {",
+				"return time.Parse(layout, value)",
+				"func ParseTimeR(layout string, value string) result.R[time.Time] {",
+			},
+		},
+		{name: "no value", spec: "os.Remove", err: true},
+		{name: "no function", spec: "os.Args", err: true},
+		{name: "no such parameter", spec: "strconv.Atoi@1", err: true},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := parseSpec(tc.spec)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			src, err := generate(imp, "x", []spec{s})
+			if (err != nil) != tc.err {
+				t.Fatalf("got %v, want error: %v", err, tc.err)
+			}
+			for _, e := range tc.expected {
+				if !strings.Contains(string(src), e) {
+					t.Fatalf("got\n%s\nwant it to contain %q", src, e)
+				}
+			}
+			for _, e := range tc.unexpected {
+				if strings.Contains(string(src), e) {
+					t.Fatalf("got\n%s\nwant it not to contain %q", src, e)
+				}
+			}
+		})
+	}
+}
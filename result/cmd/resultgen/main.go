@@ -0,0 +1,58 @@
+// Command resultgen generates wrappers for functions returning `(T, error)`.
+//
+// For every function it emits a `then.FN` taking the function's input
+// parameter as well as a function returning a `result.R[T]`. Functions with a
+// single parameter already are a `then.FN`, so they are wrapped as they are:
+//
+//	resultgen -pkg std -o std_gen.go strconv.Atoi ParseTime=time.Parse@1
+//
+// generates
+//
+//	func Atoi(s string) (int, error)
+//	func AtoiR(s string) result.R[int]
+//	func ParseTime(layout string) then.FN[string, time.Time]
+//	func ParseTimeR(layout string, value string) result.R[time.Time]
+//
+// Functions are given as `[Name=]importpath.Func[@input]`, where `Name`
+// defaults to `Func` and `input` is the index of the parameter that becomes
+// the input of the `then.FN` (default 0). All other parameters are taken by
+// the function returning the `then.FN`.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/importer"
+	"go/token"
+	"log"
+	"os"
+)
+
+func main() {
+	pkg := flag.String("pkg", "", "name of the generated package")
+	out := flag.String("o", "", "output file (default stdout)")
+	flag.Parse()
+	if *pkg == "" || flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: resultgen -pkg name [-o file] [Name=]importpath.Func[@input]...")
+		os.Exit(2)
+	}
+	specs := make([]spec, flag.NArg())
+	for i, arg := range flag.Args() {
+		s, err := parseSpec(arg)
+		if err != nil {
+			log.Fatal(err)
+		}
+		specs[i] = s
+	}
+	src, err := generate(importer.ForCompiler(token.NewFileSet(), "source", nil), *pkg, specs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,182 @@
+// Package dag runs result-producing tasks that depend on each other's
+// outputs.
+//
+// Tasks are added to a `Graph` under a unique name and refer to the outputs
+// of the tasks they depend on through typed `Ref`s. `Graph.Run` checks the
+// graph for unknown dependencies, type mismatches and cycles before running
+// anything, then runs every task as soon as its dependencies are done, with a
+// bounded number of tasks running concurrently.
+package dag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kdungs/go-result/result"
+)
+
+// Dep is a dependency of a task. It is implemented by `Ref`.
+type Dep interface {
+	ref() (string, reflect.Type)
+}
+
+// Ref refers to the output of type T of the task called `Name`.
+type Ref[T any] struct {
+	Name string
+}
+
+// Output returns a reference to the output of the task called `name`. It
+// allows referring to tasks that have not been added yet.
+func Output[T any](name string) Ref[T] {
+	return Ref[T]{name}
+}
+
+func (r Ref[T]) ref() (string, reflect.Type) {
+	return r.Name, reflect.TypeFor[T]()
+}
+
+// Get returns the output of the referenced task. It panics if the task is not
+// a declared dependency of the task that `in` was passed to.
+func (r Ref[T]) Get(in Inputs) T {
+	v, ok := in.values[r.Name]
+	if !ok {
+		panic(fmt.Sprintf("dag: %q is not a dependency", r.Name))
+	}
+	// A nil interface value is a valid output, so don't assert that v is a T.
+	t, _ := v.(T)
+	return t
+}
+
+// Inputs holds the outputs of the dependencies of a task. Use `Ref.Get` to
+// access them.
+type Inputs struct {
+	values map[string]any
+}
+
+type task struct {
+	name string
+	deps []string
+	// types holds the expected output type of each dependency.
+	types []reflect.Type
+	out   reflect.Type
+	run   func(context.Context, Inputs) (any, error)
+	// wrap turns an output into a `result.R` of the task's output type.
+	wrap func(any, error) any
+}
+
+// Graph is a set of tasks. The zero value is an empty graph ready to use.
+type Graph struct {
+	tasks  []*task
+	byName map[string]*task
+	errs   []error
+}
+
+// Add adds the task `f` called `name` with dependencies `deps` to `g`. `f`
+// may only access the outputs of `deps`. Errors such as duplicate names are
+// reported by `Graph.Validate` and `Graph.Run`.
+func Add[T any](g *Graph, name string, deps []Dep, f func(context.Context, Inputs) result.R[T]) Ref[T] {
+	if g.byName == nil {
+		g.byName = make(map[string]*task)
+	}
+	if _, ok := g.byName[name]; ok {
+		g.errs = append(g.errs, fmt.Errorf("duplicate task %q", name))
+		return Ref[T]{name}
+	}
+	t := &task{
+		name: name,
+		out:  reflect.TypeFor[T](),
+		run: func(ctx context.Context, in Inputs) (any, error) {
+			return f(ctx, in).Unwrap()
+		},
+		wrap: func(v any, err error) any {
+			t, _ := v.(T)
+			return result.Wrap(t, err)
+		},
+	}
+	for _, d := range deps {
+		n, typ := d.ref()
+		t.deps = append(t.deps, n)
+		t.types = append(t.types, typ)
+	}
+	g.tasks = append(g.tasks, t)
+	g.byName[name] = t
+	return Ref[T]{name}
+}
+
+// CycleError is returned when tasks depend on each other in a cycle.
+type CycleError struct {
+	// Path lists the tasks of the cycle; the first task is repeated at the
+	// end.
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	return "dependency cycle: " + strings.Join(e.Path, " -> ")
+}
+
+// Validate checks `g` for duplicate tasks, unknown dependencies, dependencies
+// whose type doesn't match the output of the task they refer to, and cycles.
+func (g *Graph) Validate() error {
+	errs := append([]error(nil), g.errs...)
+	for _, t := range g.tasks {
+		for i, d := range t.deps {
+			dt, ok := g.byName[d]
+			switch {
+			case !ok:
+				errs = append(errs, fmt.Errorf("task %q depends on unknown task %q", t.name, d))
+			case dt.out != t.types[i]:
+				errs = append(errs, fmt.Errorf("task %q expects %v from %q which outputs %v", t.name, t.types[i], d, dt.out))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if path := g.cycle(); path != nil {
+		return &CycleError{Path: path}
+	}
+	return nil
+}
+
+// cycle returns the first cycle found by a depth-first search or nil.
+func (g *Graph) cycle() []string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[*task]int, len(g.tasks))
+	var stack []string
+	var visit func(t *task) []string
+	visit = func(t *task) []string {
+		switch state[t] {
+		case visiting:
+			for i, n := range stack {
+				if n == t.name {
+					return append(append([]string(nil), stack[i:]...), t.name)
+				}
+			}
+		case done:
+			return nil
+		}
+		state[t] = visiting
+		stack = append(stack, t.name)
+		for _, d := range t.deps {
+			if path := visit(g.byName[d]); path != nil {
+				return path
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[t] = done
+		return nil
+	}
+	for _, t := range g.tasks {
+		if path := visit(t); path != nil {
+			return path
+		}
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+package dag_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kdungs/go-result/result"
+	"github.com/kdungs/go-result/result/dag"
+)
+
+func value[T any](v T) func(context.Context, dag.Inputs) result.R[T] {
+	return func(context.Context, dag.Inputs) result.R[T] { return result.Of(v) }
+}
+
+func failing[T any](err error) func(context.Context, dag.Inputs) result.R[T] {
+	return func(context.Context, dag.Inputs) result.R[T] { return result.OfErr[T](err) }
+}
+
+func TestRun(t *testing.T) {
+	var g dag.Graph
+	a := dag.Add(&g, "a", nil, value(2))
+	b := dag.Add(&g, "b", []dag.Dep{a}, func(_ context.Context, in dag.Inputs) result.R[int] {
+		return result.Of(a.Get(in) * 3)
+	})
+	c := dag.Add(&g, "c", []dag.Dep{a}, func(_ context.Context, in dag.Inputs) result.R[string] {
+		return result.Of(strconv.Itoa(a.Get(in)))
+	})
+	d := dag.Add(&g, "d", []dag.Dep{b, c}, func(_ context.Context, in dag.Inputs) result.R[string] {
+		return result.Of(c.Get(in) + "*3=" + strconv.Itoa(b.Get(in)))
+	})
+
+	rep, err := g.Run(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if got, err := dag.Result(rep, d).Unwrap(); err != nil || got != "2*3=6" {
+		t.Fatalf("got (%q, %v), want (%q, nil)", got, err, "2*3=6")
+	}
+	if got, want := rep.Names(), []string{"a", "b", "c", "d"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if rep.Failed() != nil || rep.Causes() != nil {
+		t.Fatalf("got (%v, %v), want (nil, nil)", rep.Failed(), rep.Causes())
+	}
+}
+
+func TestRunNilInterface(t *testing.T) {
+	var g dag.Graph
+	a := dag.Add(&g, "a", nil, value[io.Reader](nil))
+	b := dag.Add(&g, "b", []dag.Dep{a}, func(_ context.Context, in dag.Inputs) result.R[bool] {
+		return result.Of(a.Get(in) == nil)
+	})
+
+	rep, err := g.Run(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if got, err := dag.Result(rep, b).Unwrap(); err != nil || !got {
+		t.Fatalf("got (%v, %v), want (true, nil)", got, err)
+	}
+}
+
+func TestResult(t *testing.T) {
+	var g dag.Graph
+	dag.Add(&g, "a", nil, value(1))
+	rep, err := g.Run(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	cases := []struct {
+		name        string
+		ref         dag.Ref[string]
+		expectedErr string
+	}{
+		{
+			name:        "unknown task",
+			ref:         dag.Output[string]("b"),
+			expectedErr: `unknown task "b"`,
+		},
+		{
+			name:        "type mismatch",
+			ref:         dag.Output[string]("a"),
+			expectedErr: `task "a" does not output string`,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := dag.Result(rep, tc.ref).Unwrap(); err == nil || err.Error() != tc.expectedErr {
+				t.Fatalf("got %v, want %q", err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		build    func(g *dag.Graph)
+		expected string
+	}{
+		{
+			name: "duplicate",
+			build: func(g *dag.Graph) {
+				dag.Add(g, "a", nil, value(1))
+				dag.Add(g, "a", nil, value(2))
+			},
+			expected: `duplicate task "a"`,
+		},
+		{
+			name: "unknown dependency",
+			build: func(g *dag.Graph) {
+				dag.Add(g, "a", []dag.Dep{dag.Output[int]("b")}, value(1))
+			},
+			expected: `task "a" depends on unknown task "b"`,
+		},
+		{
+			name: "type mismatch",
+			build: func(g *dag.Graph) {
+				dag.Add(g, "a", nil, value("x"))
+				dag.Add(g, "b", []dag.Dep{dag.Output[int]("a")}, value(1))
+			},
+			expected: `task "b" expects int from "a" which outputs string`,
+		},
+		{
+			name: "cycle",
+			build: func(g *dag.Graph) {
+				dag.Add(g, "a", nil, value(1))
+				dag.Add(g, "b", []dag.Dep{dag.Output[int]("a"), dag.Output[int]("d")}, value(1))
+				dag.Add(g, "c", []dag.Dep{dag.Output[int]("b")}, value(1))
+				dag.Add(g, "d", []dag.Dep{dag.Output[int]("c")}, value(1))
+			},
+			expected: "dependency cycle: b -> d -> c -> b",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var g dag.Graph
+			tc.build(&g)
+			_, err := g.Run(context.Background(), 0)
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("got %v, want %q", err, tc.expected)
+			}
+		})
+	}
+
+	var g dag.Graph
+	dag.Add(&g, "a", []dag.Dep{dag.Output[int]("a")}, value(1))
+	var cerr *dag.CycleError
+	if err := g.Validate(); !errors.As(err, &cerr) || !reflect.DeepEqual(cerr.Path, []string{"a", "a"}) {
+		t.Fatalf("got %v, want cycle [a a]", err)
+	}
+}
+
+func TestRunPartialFailure(t *testing.T) {
+	errA := errors.New("a")
+	var g dag.Graph
+	a := dag.Add(&g, "a", nil, failing[int](errA))
+	b := dag.Add(&g, "b", []dag.Dep{a}, value(1))
+	dag.Add(&g, "c", []dag.Dep{b}, value(1))
+	x := dag.Add(&g, "x", nil, value(1))
+	y := dag.Add(&g, "y", []dag.Dep{x}, value("y"))
+
+	rep, err := g.Run(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if got, want := rep.Failed(), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, err := dag.Result(rep, y).Unwrap(); err != nil || got != "y" {
+		t.Fatalf("got (%q, %v), want (%q, nil)", got, err, "y")
+	}
+
+	var df *dag.DependencyFailed
+	if err := rep.Err("c"); !errors.As(err, &df) || df.Task != "c" || df.Dependency != "b" || !errors.Is(err, errA) {
+		t.Fatalf("got %v, want c skipped because of b, caused by %v", err, errA)
+	}
+	if _, err := dag.Result(rep, b).Unwrap(); err == nil {
+		t.Fatalf("got nil, want error")
+	}
+	if err := rep.Causes(); err == nil || err.Error() != `task "a": a` {
+		t.Fatalf("got %v, want %q", err, `task "a": a`)
+	}
+}
+
+func TestRunCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started := make(chan struct{})
+	var g dag.Graph
+	a := dag.Add(&g, "a", nil, func(ctx context.Context, _ dag.Inputs) result.R[int] {
+		close(started)
+		<-ctx.Done()
+		return result.OfErr[int](ctx.Err())
+	})
+	b := dag.Add(&g, "b", nil, func(context.Context, dag.Inputs) result.R[int] {
+		t.Errorf("got call to b, want none after cancellation")
+		return result.Of(1)
+	})
+	dag.Add(&g, "c", []dag.Dep{a}, value(1))
+
+	go func() {
+		<-started
+		cancel()
+	}()
+	rep, err := g.Run(ctx, 1)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if _, err := dag.Result(rep, a).Unwrap(); err == nil {
+		t.Fatalf("got nil, want error")
+	}
+	if _, err := dag.Result(rep, b).Unwrap(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+	var df *dag.DependencyFailed
+	if err := rep.Err("c"); !errors.As(err, &df) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want c skipped because of %v", err, context.Canceled)
+	}
+}
+
+func TestRunWorkerLimit(t *testing.T) {
+	const workers = 3
+	var (
+		mu      sync.Mutex
+		current int
+		peak    int
+		calls   atomic.Int32
+	)
+	var g dag.Graph
+	var deps []dag.Dep
+	for i := range 20 {
+		deps = append(deps, dag.Add(&g, strconv.Itoa(i), nil, func(context.Context, dag.Inputs) result.R[int] {
+			mu.Lock()
+			current++
+			peak = max(peak, current)
+			mu.Unlock()
+			calls.Add(1)
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			current--
+			mu.Unlock()
+			return result.Of(i)
+		}))
+	}
+	sum := dag.Add(&g, "sum", deps, func(_ context.Context, in dag.Inputs) result.R[int] {
+		s := 0
+		for _, d := range deps {
+			s += d.(dag.Ref[int]).Get(in)
+		}
+		return result.Of(s)
+	})
+
+	rep, err := g.Run(context.Background(), workers)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if got, err := dag.Result(rep, sum).Unwrap(); err != nil || got != 190 {
+		t.Fatalf("got (%d, %v), want (190, nil)", got, err)
+	}
+	if calls.Load() != 20 || peak > workers {
+		t.Fatalf("got %d calls with up to %d concurrent, want 20 with at most %d", calls.Load(), peak, workers)
+	}
+}
@@ -0,0 +1,177 @@
+package dag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/kdungs/go-result/result"
+)
+
+// DependencyFailed is the error of tasks that were skipped because one of
+// their dependencies failed. It unwraps to the error of that dependency.
+type DependencyFailed struct {
+	Task, Dependency string
+	Err              error
+}
+
+func (e *DependencyFailed) Error() string {
+	return fmt.Sprintf("task %q skipped: dependency %q failed: %v", e.Task, e.Dependency, e.Err)
+}
+
+func (e *DependencyFailed) Unwrap() error {
+	return e.Err
+}
+
+// Report holds the result of every task of a run.
+type Report struct {
+	names   []string
+	results map[string]any
+	errs    map[string]error
+}
+
+// Names returns the names of all tasks in the order in which they were added.
+func (r *Report) Names() []string {
+	return r.names
+}
+
+// Err returns the error of the task called `name` or nil if it succeeded.
+func (r *Report) Err(name string) error {
+	return r.errs[name]
+}
+
+// Failed returns the names of all tasks that failed, including skipped ones,
+// in the order in which they were added.
+func (r *Report) Failed() []string {
+	var failed []string
+	for _, n := range r.names {
+		if r.errs[n] != nil {
+			failed = append(failed, n)
+		}
+	}
+	return failed
+}
+
+// Causes returns the errors of all tasks that failed by themselves, i.e. that
+// were not skipped because of a failed dependency, joined together.
+func (r *Report) Causes() error {
+	var errs []error
+	for _, n := range r.names {
+		var df *DependencyFailed
+		if err := r.errs[n]; err != nil && !(errors.As(err, &df) && df.Task == n) {
+			errs = append(errs, fmt.Errorf("task %q: %w", n, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Result returns the result of the task referred to by `ref`. It holds an
+// error if there is no such task or if its output is not a T.
+func Result[T any](r *Report, ref Ref[T]) result.R[T] {
+	res, ok := r.results[ref.Name]
+	if !ok {
+		return result.OfErr[T](fmt.Errorf("unknown task %q", ref.Name))
+	}
+	rt, ok := res.(result.R[T])
+	if !ok {
+		return result.OfErr[T](fmt.Errorf("task %q does not output %v", ref.Name, reflect.TypeFor[T]()))
+	}
+	return rt
+}
+
+type done struct {
+	t   *task
+	v   any
+	err error
+}
+
+// Run validates `g` and runs all of its tasks with at most `workers` tasks
+// running at a time; if `workers` is not positive, there is no limit.
+//
+// Tasks whose dependencies failed are skipped with a `*DependencyFailed`
+// error; independent tasks are still run. Once `ctx` is done, tasks that have
+// not started yet fail with the context's error. An error is only returned if
+// the graph is invalid, in which case no task is run.
+func (g *Graph) Run(ctx context.Context, workers int) (*Report, error) {
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	if workers <= 0 {
+		workers = len(g.tasks)
+	}
+
+	rep := &Report{
+		names:   make([]string, len(g.tasks)),
+		results: make(map[string]any, len(g.tasks)),
+		errs:    make(map[string]error, len(g.tasks)),
+	}
+	values := make(map[string]any, len(g.tasks))
+	pending := make(map[*task]int, len(g.tasks))
+	dependents := make(map[string][]*task)
+	var ready []*task
+	for i, t := range g.tasks {
+		rep.names[i] = t.name
+		pending[t] = len(t.deps)
+		for _, d := range t.deps {
+			dependents[d] = append(dependents[d], t)
+		}
+		if len(t.deps) == 0 {
+			ready = append(ready, t)
+		}
+	}
+
+	results := make(chan done)
+	running, finished := 0, 0
+	finish := func(d done) {
+		finished++
+		rep.results[d.t.name] = d.t.wrap(d.v, d.err)
+		if d.err != nil {
+			rep.errs[d.t.name] = d.err
+		} else {
+			values[d.t.name] = d.v
+		}
+		for _, dt := range dependents[d.t.name] {
+			if pending[dt]--; pending[dt] == 0 {
+				ready = append(ready, dt)
+			}
+		}
+	}
+	for finished < len(g.tasks) {
+		for len(ready) > 0 && running < workers {
+			t := ready[0]
+			ready = ready[1:]
+			if err := g.precondition(ctx, t, rep); err != nil {
+				finish(done{t, nil, err})
+				continue
+			}
+			in := Inputs{make(map[string]any, len(t.deps))}
+			for _, d := range t.deps {
+				in.values[d] = values[d]
+			}
+			running++
+			go func() {
+				v, err := t.run(ctx, in)
+				results <- done{t, v, err}
+			}()
+		}
+		if running == 0 {
+			// Everything left was resolved without running.
+			continue
+		}
+		d := <-results
+		running--
+		finish(d)
+	}
+	return rep, nil
+}
+
+// precondition returns the error with which `t` fails without being run.
+func (g *Graph) precondition(ctx context.Context, t *task, rep *Report) error {
+	for _, d := range t.deps {
+		if err := rep.errs[d]; err != nil {
+			return &DependencyFailed{Task: t.name, Dependency: d, Err: err}
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,145 @@
+// Package diag defines a result type `R[T]` that carries non-fatal
+// diagnostics (warnings, notes) alongside a value or an error.
+//
+// Combining results with `Map`, `MapR` or `Zip` concatenates their
+// diagnostics in order, so a pipeline that succeeds with caveats reports all
+// of them at the end. Use `Render` to print them.
+package diag
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Severity is the severity of a `Diagnostic`.
+type Severity int
+
+const (
+	Info Severity = iota
+	Warning
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Pos is a position in a source file. Line and column start at 1; the zero
+// value means that the position is unknown.
+type Pos struct {
+	File      string
+	Line, Col int
+}
+
+// IsValid reports whether `p` is known.
+func (p Pos) IsValid() bool {
+	return p.Line > 0
+}
+
+func (p Pos) String() string {
+	s := p.File
+	if p.IsValid() {
+		s += fmt.Sprintf(":%d", p.Line)
+		if p.Col > 0 {
+			s += fmt.Sprintf(":%d", p.Col)
+		}
+	}
+	return s
+}
+
+// Diagnostic is a non-fatal message about a computation.
+type Diagnostic struct {
+	Severity Severity
+	Pos      Pos
+	Msg      string
+}
+
+// Warnf returns a warning with a formatted message.
+func Warnf(format string, args ...any) Diagnostic {
+	return Diagnostic{Severity: Warning, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Infof returns an informational diagnostic with a formatted message.
+func Infof(format string, args ...any) Diagnostic {
+	return Diagnostic{Severity: Info, Msg: fmt.Sprintf(format, args...)}
+}
+
+// At returns `d` located at `pos`.
+func (d Diagnostic) At(pos Pos) Diagnostic {
+	d.Pos = pos
+	return d
+}
+
+func (d Diagnostic) String() string {
+	if d.Pos == (Pos{}) {
+		return fmt.Sprintf("%v: %s", d.Severity, d.Msg)
+	}
+	return fmt.Sprintf("%v: %v: %s", d.Pos, d.Severity, d.Msg)
+}
+
+// R is a generic result that can either hold a value of type T or an error,
+// plus any number of diagnostics.
+type R[T any] struct {
+	err   error
+	v     T
+	diags []Diagnostic
+}
+
+// Unwrap returns both the value and the error component of `r`.
+func (r R[T]) Unwrap() (T, error) {
+	return r.v, r.err
+}
+
+// Or returns the value part of `r` or the provided default value `d` in case
+// `r` is actually holding an error.
+func (r R[T]) Or(d T) T {
+	if r.err != nil {
+		return d
+	}
+	return r.v
+}
+
+// Diagnostics returns the diagnostics of `r` in the order they were added.
+func (r R[T]) Diagnostics() []Diagnostic {
+	return slices.Clip(r.diags)
+}
+
+// With returns `r` with `diags` appended to its diagnostics.
+func (r R[T]) With(diags ...Diagnostic) R[T] {
+	r.diags = concat(r.diags, diags)
+	return r
+}
+
+// Wrap takes a value and an error and turns them into an `R[T]` with the
+// diagnostics `diags`.
+func Wrap[T any](v T, err error, diags ...Diagnostic) R[T] {
+	return R[T]{err: err, v: v, diags: diags}
+}
+
+// Of constructs an `R[T]` from a value of type `T` and optional diagnostics.
+func Of[T any](v T, diags ...Diagnostic) R[T] {
+	return Wrap(v, nil, diags...)
+}
+
+// OfErr constructs an `R[T]` holding an error and optional diagnostics.
+// Since `T` cannot be inferred, calling code is required to provide it.
+func OfErr[T any](err error, diags ...Diagnostic) R[T] {
+	return Wrap(*new(T), err, diags...)
+}
+
+// concat returns a new slice holding `a` followed by `b`, so that results
+// sharing a prefix of diagnostics never overwrite each other's.
+func concat(a, b []Diagnostic) []Diagnostic {
+	if len(b) == 0 {
+		return a
+	}
+	if len(a) == 0 {
+		return b
+	}
+	return append(slices.Clip(a), b...)
+}
@@ -0,0 +1,144 @@
+package diag_test
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kdungs/go-result/result"
+	"github.com/kdungs/go-result/result/diag"
+)
+
+func messages(ds []diag.Diagnostic) []string {
+	var msgs []string
+	for _, d := range ds {
+		msgs = append(msgs, d.Msg)
+	}
+	return msgs
+}
+
+func TestMapR(t *testing.T) {
+	errX := errors.New("x")
+	parse := func(s string) diag.R[int] {
+		n, err := strconv.Atoi(s)
+		return diag.Wrap(n, err, diag.Infof("parsed %s", s))
+	}
+	cases := []struct {
+		name         string
+		r            diag.R[string]
+		expectedVal  int
+		expectedErr  bool
+		expectedMsgs []string
+	}{
+		{
+			name:         "value",
+			r:            diag.Of("042", diag.Warnf("leading zero")),
+			expectedVal:  42,
+			expectedMsgs: []string{"leading zero", "parsed 042"},
+		},
+		{
+			name:         "error in f",
+			r:            diag.Of("x", diag.Warnf("suspicious")),
+			expectedErr:  true,
+			expectedMsgs: []string{"suspicious", "parsed x"},
+		},
+		{
+			name:         "error",
+			r:            diag.OfErr[string](errX, diag.Warnf("before")),
+			expectedErr:  true,
+			expectedMsgs: []string{"before"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			r := diag.Map(diag.MapR(tc.r, parse), func(n int) int { return n })
+			v, err := r.Unwrap()
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("got %v, want error: %v", err, tc.expectedErr)
+			}
+			if err == nil && v != tc.expectedVal {
+				t.Fatalf("got %d, want %d", v, tc.expectedVal)
+			}
+			if got := messages(r.Diagnostics()); !reflect.DeepEqual(got, tc.expectedMsgs) {
+				t.Fatalf("got %q, want %q", got, tc.expectedMsgs)
+			}
+		})
+	}
+}
+
+func TestZip(t *testing.T) {
+	errB := errors.New("b")
+	base := diag.Of(1, diag.Warnf("base"))
+	// Both results share the diagnostics of base; appending to one must not
+	// affect the other.
+	a := base.With(diag.Warnf("a"))
+	b := diag.MapR(base, func(int) diag.R[int] { return diag.OfErr[int](errB, diag.Warnf("b")) })
+
+	r := diag.Zip(a, b, func(x, y int) int { return x + y })
+	if _, err := r.Unwrap(); err != errB {
+		t.Fatalf("got %v, want %v", err, errB)
+	}
+	if got, want := messages(r.Diagnostics()), []string{"base", "a", "base", "b"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	r = diag.ZipR(a, a, func(x, y int) diag.R[int] { return diag.Of(x+y, diag.Infof("sum")) })
+	if v, err := r.Unwrap(); err != nil || v != 2 {
+		t.Fatalf("got (%d, %v), want (2, nil)", v, err)
+	}
+	if got, want := messages(r.Diagnostics()), []string{"base", "a", "base", "a", "sum"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestBridges(t *testing.T) {
+	if v, err := diag.From(result.Of(1)).With(diag.Warnf("w")).Result().Unwrap(); err != nil || v != 1 {
+		t.Fatalf("got (%d, %v), want (1, nil)", v, err)
+	}
+
+	truncate := func(s string) diag.R[string] {
+		if len(s) > 3 {
+			return diag.Of(s[:3], diag.Warnf("truncated %q", s))
+		}
+		return diag.Of(s)
+	}
+	f := diag.Chain(diag.Lift(func(s string) (string, error) { return strings.TrimSpace(s), nil }), truncate)
+
+	var reported [][]string
+	g := diag.Report(f, func(ds []diag.Diagnostic) { reported = append(reported, messages(ds)) })
+	for _, in := range []string{" abcdef ", "ab"} {
+		if _, err := g(in); err != nil {
+			t.Fatalf("got %v, want nil", err)
+		}
+	}
+	if want := [][]string{{`truncated "abcdef"`}}; !reflect.DeepEqual(reported, want) {
+		t.Fatalf("got %q, want %q", reported, want)
+	}
+}
+
+func TestRender(t *testing.T) {
+	src := "name: x\n\tcolour: red\n"
+	diags := []diag.Diagnostic{
+		diag.Warnf(`field "colour" is deprecated`).At(diag.Pos{File: "cfg.yaml", Line: 2, Col: 2}),
+		diag.Infof("defaults applied").At(diag.Pos{File: "cfg.yaml"}),
+		diag.Warnf("unknown source").At(diag.Pos{File: "other.yaml", Line: 1, Col: 1}),
+		diag.Infof("no position"),
+	}
+	var b strings.Builder
+	if err := diag.Render(&b, diags, map[string]string{"cfg.yaml": src}); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	expected := `cfg.yaml:2:2: warning: field "colour" is deprecated
+	` + "\tcolour: red" + `
+	` + "\t^" + `
+cfg.yaml: info: defaults applied
+other.yaml:1:1: warning: unknown source
+info: no position
+`
+	if b.String() != expected {
+		t.Fatalf("got\n%s\nwant\n%s", b.String(), expected)
+	}
+}
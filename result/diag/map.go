@@ -0,0 +1,94 @@
+package diag
+
+import (
+	"github.com/kdungs/go-result/result"
+	"github.com/kdungs/go-result/then"
+)
+
+// Map applies an error-free function to the value of `r`. The diagnostics of
+// `r` are kept.
+func Map[A, B any](r R[A], f func(A) B) R[B] {
+	if r.err != nil {
+		return OfErr[B](r.err, r.diags...)
+	}
+	return Of(f(r.v), r.diags...)
+}
+
+// MapR applies a function returning a result to the value of `r`. The
+// diagnostics of the returned result follow those of `r`.
+func MapR[A, B any](r R[A], f func(A) R[B]) R[B] {
+	if r.err != nil {
+		return OfErr[B](r.err, r.diags...)
+	}
+	rb := f(r.v)
+	rb.diags = concat(r.diags, rb.diags)
+	return rb
+}
+
+// Zip combines two results into one by applying a binary function to both
+// values in case they exist. Otherwise returns whichever error is encountered
+// first. The diagnostics of both results are kept, those of `ra` first.
+func Zip[A, B, C any](ra R[A], rb R[B], f func(A, B) C) R[C] {
+	diags := concat(ra.diags, rb.diags)
+	if ra.err != nil {
+		return OfErr[C](ra.err, diags...)
+	}
+	if rb.err != nil {
+		return OfErr[C](rb.err, diags...)
+	}
+	return Of(f(ra.v, rb.v), diags...)
+}
+
+// ZipR does the same as `Zip` for a binary function returning a result. Its
+// diagnostics follow those of `ra` and `rb`.
+func ZipR[A, B, C any](ra R[A], rb R[B], f func(A, B) R[C]) R[C] {
+	diags := concat(ra.diags, rb.diags)
+	if ra.err != nil {
+		return OfErr[C](ra.err, diags...)
+	}
+	if rb.err != nil {
+		return OfErr[C](rb.err, diags...)
+	}
+	rc := f(ra.v, rb.v)
+	rc.diags = concat(diags, rc.diags)
+	return rc
+}
+
+// From converts a `result.R[T]` to an `R[T]` without diagnostics.
+func From[T any](r result.R[T]) R[T] {
+	return Wrap(r.Unwrap())
+}
+
+// Result converts `r` to a `result.R[T]`, dropping its diagnostics.
+func (r R[T]) Result() result.R[T] {
+	return result.Wrap(r.v, r.err)
+}
+
+// FN is a result function that reports diagnostics.
+type FN[A, B any] func(A) R[B]
+
+// Lift turns a result function into an `FN` without diagnostics.
+func Lift[A, B any](f then.FN[A, B]) FN[A, B] {
+	return func(a A) R[B] {
+		return Wrap(f(a))
+	}
+}
+
+// Chain composes two functions, concatenating their diagnostics.
+func Chain[A, B, C any](f FN[A, B], g FN[B, C]) FN[A, C] {
+	return func(a A) R[C] {
+		return MapR(f(a), g)
+	}
+}
+
+// Report turns `f` into a plain result function that passes the diagnostics
+// of every call to `report`, e.g. a logger, before returning.
+func Report[A, B any](f FN[A, B], report func([]Diagnostic)) then.FN[A, B] {
+	return func(a A) (B, error) {
+		r := f(a)
+		if len(r.diags) > 0 {
+			report(r.Diagnostics())
+		}
+		return r.Unwrap()
+	}
+}
@@ -0,0 +1,39 @@
+package diag
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Render writes one line per diagnostic to `w`. If `sources` holds the
+// contents of the file of a diagnostic's position, the line it refers to is
+// quoted below it, with a caret marking the column.
+func Render(w io.Writer, diags []Diagnostic, sources map[string]string) error {
+	var b strings.Builder
+	for _, d := range diags {
+		fmt.Fprintln(&b, d)
+		src, ok := sources[d.Pos.File]
+		if !ok || !d.Pos.IsValid() {
+			continue
+		}
+		lines := strings.Split(src, "\n")
+		if d.Pos.Line > len(lines) {
+			continue
+		}
+		line := strings.TrimRight(lines[d.Pos.Line-1], "\r")
+		fmt.Fprintf(&b, "\t%s\n", line)
+		if d.Pos.Col > 0 && d.Pos.Col <= len(line)+1 {
+			// Keep tabs so that the caret lines up with the quoted line.
+			indent := strings.Map(func(r rune) rune {
+				if r == '\t' {
+					return r
+				}
+				return ' '
+			}, line[:d.Pos.Col-1])
+			fmt.Fprintf(&b, "\t%s^\n", indent)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,105 @@
+// Package fuzzr turns fuzz inputs into arbitrary `result.R[T]` values and
+// pipelines of result functions, so that pipelines can be fuzzed with
+// `go test -fuzz`:
+//
+//	func FuzzPipeline(f *testing.F) {
+//		f.Fuzz(func(t *testing.T, data []byte) {
+//			src := fuzzr.NewSource(data)
+//			p := fuzzr.Pipeline(src, stages)
+//			in := fuzzr.Result[Doc](src)
+//			out := result.MapE(in, p.FN)
+//			// Check invariants of out.
+//		})
+//	}
+package fuzzr
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"strings"
+
+	"github.com/kdungs/go-result/result"
+	"github.com/kdungs/go-result/then"
+)
+
+// DefaultSize is the size passed to generators by `Result`.
+const DefaultSize = 8
+
+// MaxStages is the maximum number of stages in a pipeline built by
+// `Pipeline`.
+const MaxStages = 8
+
+// Source is a source of randomness that is backed by fuzz input. Every choice
+// consumes a part of the input, so the fuzzing engine can steer the choices
+// by mutating it. Once the input is exhausted, all choices are zero.
+type Source struct {
+	data []byte
+	r    *rand.Rand
+}
+
+// NewSource returns a `Source` consuming `data`.
+func NewSource(data []byte) *Source {
+	s := &Source{data: data}
+	s.r = rand.New(s)
+	return s
+}
+
+// Rand returns a `*rand.Rand` drawing from `s`.
+func (s *Source) Rand() *rand.Rand {
+	return s.r
+}
+
+// Uint64 implements `rand.Source64`.
+func (s *Source) Uint64() uint64 {
+	var buf [8]byte
+	n := copy(buf[:], s.data)
+	s.data = s.data[n:]
+	return binary.LittleEndian.Uint64(buf[:])
+}
+
+// Int63 implements `rand.Source`.
+func (s *Source) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// Seed implements `rand.Source`. It is a no-op since all randomness comes from
+// the fuzz input.
+func (s *Source) Seed(int64) {}
+
+// Result generates an arbitrary `result.R[T]` using its `Generate` method.
+func Result[T any](s *Source) result.R[T] {
+	return result.R[T]{}.Generate(s.Rand(), DefaultSize).Interface().(result.R[T])
+}
+
+// Stage is a named result function that `Pipeline` can choose from.
+type Stage[T any] struct {
+	Name string
+	FN   then.FN[T, T]
+}
+
+// Built is a pipeline built by `Pipeline`.
+type Built[T any] struct {
+	// Stages holds the names of the chosen stages in order.
+	Stages []string
+	// FN is the composition of the chosen stages using `then.Chain`.
+	FN then.FN[T, T]
+}
+
+func (b Built[T]) String() string {
+	return strings.Join(b.Stages, " | ")
+}
+
+// Pipeline chains up to `MaxStages` stages chosen from `stages`, possibly
+// repeating some of them. An empty pipeline is the identity.
+func Pipeline[T any](s *Source, stages []Stage[T]) Built[T] {
+	b := Built[T]{FN: then.Lift(func(x T) T { return x })}
+	if len(stages) == 0 {
+		return b
+	}
+	for n := s.Rand().Intn(MaxStages + 1); n > 0; n-- {
+		st := stages[s.Rand().Intn(len(stages))]
+		b.Stages = append(b.Stages, st.Name)
+		b.FN = then.Chain(b.FN, st.FN)
+	}
+	return b
+}
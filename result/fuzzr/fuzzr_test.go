@@ -0,0 +1,73 @@
+package fuzzr_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kdungs/go-result/result"
+	"github.com/kdungs/go-result/result/fuzzr"
+)
+
+var errEmpty = errors.New("empty")
+
+var stages = []fuzzr.Stage[string]{
+	{Name: "upper", FN: func(s string) (string, error) { return strings.ToUpper(s), nil }},
+	{Name: "trim", FN: func(s string) (string, error) { return strings.TrimSpace(s), nil }},
+	{Name: "double", FN: func(s string) (string, error) { return s + s, nil }},
+	{Name: "nonempty", FN: func(s string) (string, error) {
+		if s == "" {
+			return "", errEmpty
+		}
+		return s, nil
+	}},
+}
+
+func TestSourceIsDeterministic(t *testing.T) {
+	data := []byte("some fuzz input that is long enough")
+	a := fuzzr.NewSource(data)
+	b := fuzzr.NewSource(data)
+	pa, pb := fuzzr.Pipeline(a, stages), fuzzr.Pipeline(b, stages)
+	if !reflect.DeepEqual(pa.Stages, pb.Stages) {
+		t.Fatalf("got %v and %v, want equal pipelines", pa, pb)
+	}
+	ra, rb := fuzzr.Result[[]int](a), fuzzr.Result[[]int](b)
+	if !reflect.DeepEqual(ra, rb) {
+		t.Fatalf("got %v and %v, want equal results", ra, rb)
+	}
+}
+
+func TestExhaustedSource(t *testing.T) {
+	s := fuzzr.NewSource(nil)
+	if p := fuzzr.Pipeline(s, stages); len(p.Stages) != 0 {
+		t.Fatalf("got %v, want empty pipeline", p)
+	}
+	if v, err := fuzzr.Result[int](s).Unwrap(); err != nil || v != 0 {
+		t.Fatalf("got (%d, %v), want (0, nil)", v, err)
+	}
+}
+
+func FuzzPipeline(f *testing.F) {
+	f.Add([]byte("\x01\x00\x00\x00\x00\x00\x00\x00 hello "))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		src := fuzzr.NewSource(data)
+		p := fuzzr.Pipeline(src, stages)
+		in := fuzzr.Result[string](src)
+		_, err := result.MapE(in, p.FN).Unwrap()
+		if _, inErr := in.Unwrap(); inErr != nil {
+			if err != inErr {
+				t.Fatalf("%v: got %v, want input error %v", p, err, inErr)
+			}
+			return
+		}
+		// The only error a stage can produce is `errEmpty`, which it never
+		// produces for non-empty values.
+		if err != nil && err != errEmpty {
+			t.Fatalf("%v: got unexpected error %v", p, err)
+		}
+		if err == errEmpty && strings.TrimSpace(in.Or("")) != "" {
+			t.Fatalf("%v: got %v for %q", p, err, in.Or(""))
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package result
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+)
+
+// Generate implements `quick.Generator` so that `R[T]` can be used with
+// package testing/quick. About one in four generated results holds an error.
+// Values are generated by T's own `Generate` method if it has one and
+// otherwise in the same way as `quick.Value` does. It panics if T has neither
+// a generator nor a supported kind (e.g. channels, functions or structs with
+// unexported fields).
+func (R[T]) Generate(r *rand.Rand, size int) reflect.Value {
+	size = max(size, 0)
+	if r.Intn(4) == 3 {
+		return reflect.ValueOf(OfErr[T](fmt.Errorf("generated error %d", r.Intn(size+1))))
+	}
+	t := reflect.TypeFor[T]()
+	v, err := generate(t, r, size)
+	if err != nil {
+		panic(fmt.Sprintf("result: cannot generate %v: %v", t, err))
+	}
+	return reflect.ValueOf(Of(v.Interface().(T)))
+}
+
+var errUnsupported = errors.New("unsupported kind")
+
+// generator has the same shape as `quick.Generator`. Package testing/quick is
+// not imported here because it registers command line flags.
+type generator interface {
+	Generate(r *rand.Rand, size int) reflect.Value
+}
+
+func generate(t reflect.Type, r *rand.Rand, size int) (reflect.Value, error) {
+	if g, ok := reflect.Zero(t).Interface().(generator); ok {
+		return g.Generate(r, size), nil
+	}
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt((r.Int63() >> (64 - t.Bits())) * int64(1-2*r.Intn(2)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(r.Uint64() >> (64 - t.Bits()))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(r.NormFloat64() * float64(size))
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(r.NormFloat64(), r.NormFloat64()) * complex(float64(size), 0))
+	case reflect.String:
+		rs := make([]rune, r.Intn(size+1))
+		for i := range rs {
+			rs[i] = rune(r.Intn(0x10ffff))
+		}
+		v.SetString(string(rs))
+	case reflect.Pointer:
+		if size <= 0 || r.Intn(size+1) == 0 {
+			return v, nil
+		}
+		// Unlike package testing/quick, shrink the size for every pointer so
+		// that recursive types stay small.
+		e, err := generate(t.Elem(), r, size-1)
+		if err != nil {
+			return v, err
+		}
+		v.Set(reflect.New(t.Elem()))
+		v.Elem().Set(e)
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice {
+			n := r.Intn(size + 1)
+			v.Set(reflect.MakeSlice(t, n, n))
+		}
+		// Like package testing/quick, share the size among the elements so
+		// that nested values stay small.
+		left := size - v.Len()
+		for i := 0; i < v.Len(); i++ {
+			e, err := generate(t.Elem(), r, left)
+			if err != nil {
+				return v, err
+			}
+			v.Index(i).Set(e)
+		}
+	case reflect.Map:
+		v.Set(reflect.MakeMap(t))
+		n := r.Intn(size + 1)
+		left := size - n
+		for i := 0; i < n; i++ {
+			k, err := generate(t.Key(), r, left)
+			if err != nil {
+				return v, err
+			}
+			e, err := generate(t.Elem(), r, left)
+			if err != nil {
+				return v, err
+			}
+			v.SetMapIndex(k, e)
+		}
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				return v, errUnsupported
+			}
+			e, err := generate(t.Field(i).Type, r, size)
+			if err != nil {
+				return v, err
+			}
+			v.Field(i).Set(e)
+		}
+	default:
+		return v, errUnsupported
+	}
+	return v, nil
+}
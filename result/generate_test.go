@@ -0,0 +1,62 @@
+package result_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/kdungs/go-result/result"
+)
+
+type point struct {
+	X, Y int8
+	Tags map[string][]bool
+	Next *point
+}
+
+func TestGenerate(t *testing.T) {
+	var values, errs int
+	f := func(r result.R[point]) bool {
+		if _, err := r.Unwrap(); err != nil {
+			errs++
+		} else {
+			values++
+		}
+		return true
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 400}); err != nil {
+		t.Fatal(err)
+	}
+	if values == 0 || errs == 0 {
+		t.Fatalf("got %d values and %d errors, want both", values, errs)
+	}
+}
+
+// even is a type with a custom generator.
+type even int
+
+func (even) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(even(2 * r.Intn(100)))
+}
+
+func TestGenerateUsesGenerator(t *testing.T) {
+	f := func(r result.R[even]) bool {
+		return r.Or(0)%2 == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerateUnsupported(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("got no panic, want one")
+		}
+	}()
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		result.R[chan int]{}.Generate(r, 10)
+	}
+}
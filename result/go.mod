@@ -1,3 +1,8 @@
 module github.com/kdungs/go-result/result
 
-go 1.20
+go 1.23
+
+require github.com/kdungs/go-result/then v0.0.0-00010101000000-000000000000
+
+// Build against the local copy of then until it is tagged.
+replace github.com/kdungs/go-result/then => ../then
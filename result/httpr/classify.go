@@ -0,0 +1,77 @@
+package httpr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// StatusCoder is implemented by errors that know their HTTP status code.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// Classifier maps errors to HTTP status codes. Rules are tried in the order
+// they were registered. If no rule matches, errors implementing `StatusCoder`
+// use their own status code and all others map to 500.
+//
+// The zero value is ready to use.
+type Classifier struct {
+	rules []func(error) (int, bool)
+}
+
+// Is maps errors matching `target` according to `errors.Is` to `status`.
+func (c *Classifier) Is(target error, status int) *Classifier {
+	return c.Func(func(err error) (int, bool) {
+		return status, errors.Is(err, target)
+	})
+}
+
+// Func registers an arbitrary rule. `f` reports the status code and whether
+// it applies to `err`.
+func (c *Classifier) Func(f func(err error) (int, bool)) *Classifier {
+	c.rules = append(c.rules, f)
+	return c
+}
+
+// As maps errors for which `errors.As` finds an `E` to `status`. `E` may be a
+// concrete error type or an interface, e.g. `interface{ Timeout() bool }`.
+// Since `errors.As` would panic on every request otherwise, `As` panics if
+// `E` is neither.
+func As[E any](c *Classifier, status int) *Classifier {
+	if typ := reflect.TypeFor[E](); typ.Kind() != reflect.Interface && !typ.Implements(reflect.TypeFor[error]()) {
+		panic(fmt.Sprintf("httpr: As: %v does not implement error", typ))
+	}
+	return c.Func(func(err error) (int, bool) {
+		var target E
+		return status, errors.As(err, &target)
+	})
+}
+
+// Status returns the HTTP status code for `err`.
+func (c *Classifier) Status(err error) int {
+	if status, ok := c.match(err); ok {
+		return status
+	}
+	var sc StatusCoder
+	if errors.As(err, &sc) && validStatus(sc.StatusCode()) {
+		return sc.StatusCode()
+	}
+	return http.StatusInternalServerError
+}
+
+// match returns the status code of the first rule that applies to `err`.
+func (c *Classifier) match(err error) (int, bool) {
+	for _, rule := range c.rules {
+		if status, ok := rule(err); ok {
+			return status, true
+		}
+	}
+	return 0, false
+}
+
+// validStatus reports whether `status` can be written as an HTTP status code.
+func validStatus(status int) bool {
+	return status >= 100 && status <= 599
+}
@@ -0,0 +1,71 @@
+package httpr_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/kdungs/go-result/result/httpr"
+)
+
+var errNotFound = errors.New("not found")
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string { return "timeout" }
+func (timeoutError) Timeout() bool { return true }
+
+type teapotError struct{}
+
+func (teapotError) Error() string   { return "teapot" }
+func (teapotError) StatusCode() int { return http.StatusTeapot }
+
+func TestClassifier(t *testing.T) {
+	c := (&httpr.Classifier{}).Is(errNotFound, http.StatusNotFound)
+	httpr.As[interface{ Timeout() bool }](c, http.StatusGatewayTimeout)
+	cases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{
+			name:     "sentinel",
+			err:      fmt.Errorf("user 42: %w", errNotFound),
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "interface",
+			err:      fmt.Errorf("upstream: %w", timeoutError{}),
+			expected: http.StatusGatewayTimeout,
+		},
+		{
+			name:     "status coder",
+			err:      teapotError{},
+			expected: http.StatusTeapot,
+		},
+		{
+			name:     "unknown",
+			err:      errors.New("boom"),
+			expected: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if s := c.Status(tc.err); s != tc.expected {
+				t.Fatalf("got %d, want %d", s, tc.expected)
+			}
+		})
+	}
+}
+
+func TestAsNoError(t *testing.T) {
+	defer func() {
+		want := "httpr: As: struct {} does not implement error"
+		if p := recover(); p != want {
+			t.Fatalf("got %v, want %q", p, want)
+		}
+	}()
+	httpr.As[struct{}](&httpr.Classifier{}, http.StatusTeapot)
+}
@@ -0,0 +1,127 @@
+package httpr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+
+	"github.com/kdungs/go-result/result"
+	"github.com/kdungs/go-result/then"
+)
+
+// maxExcerpt is the number of bytes of a response body kept in a
+// `StatusError`.
+const maxExcerpt = 512
+
+// StatusError is returned for responses with a status code outside of 2xx.
+type StatusError struct {
+	StatusCode int
+	Status     string
+	// Body holds the beginning of the response body.
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("unexpected status %s", e.Status)
+	}
+	return fmt.Sprintf("unexpected status %s: %s", e.Status, e.Body)
+}
+
+// Get fetches `url` using `client` and decodes the JSON response body into a
+// `T`. Responses with a status code outside of 2xx result in a
+// `*StatusError`.
+func Get[T any](ctx context.Context, client *http.Client, url string) result.R[T] {
+	return result.Wrap(then.Chain(
+		then.Chain(NewRequest(ctx, http.MethodGet, nil), Send(client)),
+		then.Chain(CheckStatus, DecodeBody[T]),
+	)(url))
+}
+
+// NewRequest is a result function that builds a request for `method` and
+// `body` to a given URL.
+func NewRequest(ctx context.Context, method string, body io.Reader) then.FN[string, *http.Request] {
+	return func(url string) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, method, url, body)
+	}
+}
+
+// WithHeader is a result function that sets the header `key` to `value`.
+func WithHeader(key, value string) then.FN[*http.Request, *http.Request] {
+	return func(r *http.Request) (*http.Request, error) {
+		r.Header.Set(key, value)
+		return r, nil
+	}
+}
+
+// Send is a result function that sends a request using `client`. If `client`
+// is nil, `http.DefaultClient` is used. The caller is responsible for closing
+// the response body, e.g. by chaining `CheckStatus` and `DecodeBody`.
+func Send(client *http.Client) then.FN[*http.Request, *http.Response] {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do
+}
+
+// CheckStatus is a result function that passes on responses with a 2xx status
+// code. Other responses are closed and turned into a `*StatusError`.
+func CheckStatus(resp *http.Response) (*http.Response, error) {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer closeBody(resp)
+	excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxExcerpt))
+	return nil, &StatusError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       string(excerpt),
+	}
+}
+
+// DecodeBody is a result function that decodes the JSON body of `resp` into a
+// `T`. The body is always closed.
+func DecodeBody[T any](resp *http.Response) (T, error) {
+	defer closeBody(resp)
+	var v T
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return *new(T), err
+	}
+	return v, nil
+}
+
+// DecodeStream decodes a stream of JSON values, e.g. newline-delimited JSON,
+// from the body of `resp`. Iteration stops after the first error, which is
+// yielded as the last element. The body is always closed.
+func DecodeStream[T any](resp result.R[*http.Response]) iter.Seq[result.R[T]] {
+	return func(yield func(result.R[T]) bool) {
+		r, err := resp.Unwrap()
+		if err != nil {
+			yield(result.OfErr[T](err))
+			return
+		}
+		defer closeBody(r)
+		dec := json.NewDecoder(r.Body)
+		for {
+			var v T
+			err := dec.Decode(&v)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(result.Wrap(v, err)) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// closeBody drains and closes the body of `resp` so that the underlying
+// connection can be reused.
+func closeBody(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	_ = resp.Body.Close()
+}
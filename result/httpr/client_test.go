@@ -0,0 +1,159 @@
+package httpr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kdungs/go-result/result"
+	"github.com/kdungs/go-result/result/httpr"
+	"github.com/kdungs/go-result/then"
+)
+
+// trackingTransport counts response bodies that are opened and closed.
+type trackingTransport struct {
+	opened, closed atomic.Int32
+}
+
+func (t *trackingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	t.opened.Add(1)
+	resp.Body = &trackingBody{resp.Body, t}
+	return resp, nil
+}
+
+type trackingBody struct {
+	io.ReadCloser
+	t *trackingTransport
+}
+
+func (b *trackingBody) Close() error {
+	b.t.closed.Add(1)
+	return b.ReadCloser.Close()
+}
+
+func newClient(t *testing.T) (*http.Client, string) {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/greeting", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"message": "Hello, %s!"}`, r.Header.Get("X-Name"))
+	})
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"message": `)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, strings.Repeat("x", 1000), http.StatusNotFound)
+	})
+	mux.HandleFunc("/stream", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "{\"message\": \"a\"}\n{\"message\": \"b\"}\n{\"message\": ")
+	})
+	srv := httptest.NewServer(mux)
+	tr := &trackingTransport{}
+	t.Cleanup(func() {
+		srv.Close()
+		if o, c := tr.opened.Load(), tr.closed.Load(); o != c {
+			t.Fatalf("got %d closed bodies, want %d", c, o)
+		}
+	})
+	return &http.Client{Transport: tr}, srv.URL
+}
+
+func TestGet(t *testing.T) {
+	cases := []struct {
+		name        string
+		path        string
+		expected    greeting
+		expectedErr bool
+		status      int
+	}{
+		{
+			name:     "value",
+			path:     "/greeting",
+			expected: greeting{"Hello, !"},
+		},
+		{
+			name:        "decoding error",
+			path:        "/broken",
+			expectedErr: true,
+		},
+		{
+			name:        "status error",
+			path:        "/missing",
+			expectedErr: true,
+			status:      http.StatusNotFound,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			client, url := newClient(t)
+			v, err := httpr.Get[greeting](context.Background(), client, url+tc.path).Unwrap()
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("got %v, want error: %v", err, tc.expectedErr)
+			}
+			if err == nil && v != tc.expected {
+				t.Fatalf("got %+v, want %+v", v, tc.expected)
+			}
+			var serr *httpr.StatusError
+			if tc.status != 0 {
+				if !errors.As(err, &serr) || serr.StatusCode != tc.status {
+					t.Fatalf("got %v, want *StatusError with status %d", err, tc.status)
+				}
+				if len(serr.Body) != 512 {
+					t.Fatalf("got body excerpt of length %d, want 512", len(serr.Body))
+				}
+			}
+		})
+	}
+}
+
+func TestClientStages(t *testing.T) {
+	client, url := newClient(t)
+	fetch := then.Chain(
+		then.Chain(
+			then.Chain(httpr.NewRequest(context.Background(), http.MethodGet, nil), httpr.WithHeader("X-Name", "Gopher")),
+			httpr.Send(client),
+		),
+		then.Chain(httpr.CheckStatus, httpr.DecodeBody[greeting]),
+	)
+	v, err := fetch(url + "/greeting")
+	if err != nil || v != (greeting{"Hello, Gopher!"}) {
+		t.Fatalf("got (%+v, %v), want ({Hello, Gopher!}, nil)", v, err)
+	}
+}
+
+func TestDecodeStream(t *testing.T) {
+	client, url := newClient(t)
+	send := then.Chain(httpr.NewRequest(context.Background(), http.MethodGet, nil), httpr.Send(client))
+	var vs []greeting
+	var err error
+	for r := range httpr.DecodeStream[greeting](result.Wrap(send(url + "/stream"))) {
+		v, rerr := r.Unwrap()
+		if rerr != nil {
+			err = rerr
+			continue
+		}
+		vs = append(vs, v)
+	}
+	if expected := []greeting{{"a"}, {"b"}}; !reflect.DeepEqual(vs, expected) {
+		t.Fatalf("got %v, want %v", vs, expected)
+	}
+	if err == nil {
+		t.Fatal("got nil, want decoding error")
+	}
+
+	// Stopping early still closes the body.
+	for range httpr.DecodeStream[greeting](result.Wrap(send(url + "/stream"))) {
+		break
+	}
+}
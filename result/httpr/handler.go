@@ -0,0 +1,82 @@
+// Package httpr adapts functions returning `result.R[T]` to package net/http.
+package httpr
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kdungs/go-result/result"
+	"github.com/kdungs/go-result/then"
+)
+
+// Handler turns `f` into an `http.Handler`. Values are rendered as JSON with
+// status 200, errors as problem details (see `ProblemFor`) using `c` to
+// determine the status code. If `c` is nil, the zero `Classifier` is used.
+func Handler[T any](f func(*http.Request) result.R[T], c *Classifier) http.Handler {
+	if c == nil {
+		c = &Classifier{}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v, err := f(r).Unwrap()
+		if err != nil {
+			WriteProblem(w, ProblemFor(c, r, err))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(v)
+	})
+}
+
+// HandlerFN is `Handler` for result functions, e.g. pipelines built with
+// `then.Chain` starting from `DecodeJSON`.
+func HandlerFN[T any](f then.FN[*http.Request, T], c *Classifier) http.Handler {
+	return Handler(func(r *http.Request) result.R[T] {
+		return result.Wrap(f(r))
+	}, c)
+}
+
+// RequestError marks errors caused by a malformed request. It maps to 400.
+type RequestError struct {
+	Err error
+}
+
+func (e *RequestError) Error() string {
+	return "bad request: " + e.Err.Error()
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode implements `StatusCoder`.
+func (e *RequestError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// DecodeJSON is a result function that decodes the body of `r` as JSON into a
+// `T`. Decoding errors are wrapped in a `*RequestError`.
+func DecodeJSON[T any](r *http.Request) (T, error) {
+	var v T
+	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+		return *new(T), &RequestError{err}
+	}
+	return v, nil
+}
+
+// Query is a result function that extracts the query parameter `name`. A
+// missing parameter is a `*RequestError`.
+func Query(name string) then.FN[*http.Request, string] {
+	return func(r *http.Request) (string, error) {
+		if !r.URL.Query().Has(name) {
+			return "", &RequestError{&missingParamError{name}}
+		}
+		return r.URL.Query().Get(name), nil
+	}
+}
+
+type missingParamError struct{ name string }
+
+func (e *missingParamError) Error() string {
+	return "missing query parameter " + e.name
+}
@@ -0,0 +1,210 @@
+package httpr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kdungs/go-result/result"
+	"github.com/kdungs/go-result/result/httpr"
+	"github.com/kdungs/go-result/then"
+)
+
+type greeting struct {
+	Message string `json:"message"`
+}
+
+type name struct {
+	Name string `json:"name"`
+}
+
+func greet(n name) (greeting, error) {
+	if n.Name == "" {
+		return greeting{}, &httpr.Problem{
+			Type:   "https://example.com/problems/anonymous",
+			Title:  "Anonymous",
+			Status: http.StatusUnprocessableEntity,
+		}
+	}
+	if n.Name == "nobody" {
+		return greeting{}, fmt.Errorf("looking up %q: %w", n.Name, errNotFound)
+	}
+	if n.Name == "nope" || n.Name == "teapot" {
+		// Problems without a status are classified like other errors.
+		return greeting{}, &httpr.Problem{Title: n.Name}
+	}
+	if n.Name == "root" {
+		return greeting{}, errors.New("database on fire")
+	}
+	return greeting{"Hello, " + n.Name + "!"}, nil
+}
+
+func TestHandlerFN(t *testing.T) {
+	c := (&httpr.Classifier{}).Is(errNotFound, http.StatusNotFound).Func(func(err error) (int, bool) {
+		var p *httpr.Problem
+		return http.StatusTeapot, errors.As(err, &p) && p.Title == "teapot"
+	})
+	h := httpr.HandlerFN(then.Chain(httpr.DecodeJSON[name], greet), c)
+	cases := []struct {
+		name            string
+		body            string
+		expectedStatus  int
+		expectedType    string
+		expectedBody    any
+		expectedProblem *httpr.Problem
+	}{
+		{
+			name:           "value",
+			body:           `{"name": "Gopher"}`,
+			expectedStatus: http.StatusOK,
+			expectedType:   "application/json",
+			expectedBody:   &greeting{"Hello, Gopher!"},
+		},
+		{
+			name:           "decoding error",
+			body:           `{"name": `,
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   "application/problem+json",
+			expectedBody: &httpr.Problem{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "bad request: unexpected EOF",
+				Instance: "/greet",
+			},
+		},
+		{
+			name:           "classified error",
+			body:           `{"name": "nobody"}`,
+			expectedStatus: http.StatusNotFound,
+			expectedType:   "application/problem+json",
+			expectedBody: &httpr.Problem{
+				Type:     "about:blank",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   `looking up "nobody": not found`,
+				Instance: "/greet",
+			},
+		},
+		{
+			name:           "internal error hides details",
+			body:           `{"name": "root"}`,
+			expectedStatus: http.StatusInternalServerError,
+			expectedType:   "application/problem+json",
+			expectedBody: &httpr.Problem{
+				Type:     "about:blank",
+				Title:    "Internal Server Error",
+				Status:   http.StatusInternalServerError,
+				Instance: "/greet",
+			},
+		},
+		{
+			name:           "problem",
+			body:           `{}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedType:   "application/problem+json",
+			expectedBody: &httpr.Problem{
+				Type:   "https://example.com/problems/anonymous",
+				Title:  "Anonymous",
+				Status: http.StatusUnprocessableEntity,
+			},
+		},
+		{
+			name:           "problem without status",
+			body:           `{"name": "nope"}`,
+			expectedStatus: http.StatusInternalServerError,
+			expectedType:   "application/problem+json",
+			expectedBody: &httpr.Problem{
+				Title:  "nope",
+				Status: http.StatusInternalServerError,
+			},
+		},
+		{
+			name:           "classified problem without status",
+			body:           `{"name": "teapot"}`,
+			expectedStatus: http.StatusTeapot,
+			expectedType:   "application/problem+json",
+			expectedBody: &httpr.Problem{
+				Title:  "teapot",
+				Status: http.StatusTeapot,
+			},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/greet", strings.NewReader(tc.body)))
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("got status %d, want %d", rec.Code, tc.expectedStatus)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != tc.expectedType {
+				t.Fatalf("got content type %q, want %q", ct, tc.expectedType)
+			}
+			body := reflect.New(reflect.TypeOf(tc.expectedBody).Elem()).Interface()
+			if err := json.Unmarshal(rec.Body.Bytes(), body); err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if !reflect.DeepEqual(body, tc.expectedBody) {
+				t.Fatalf("got %+v, want %+v", body, tc.expectedBody)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	h := httpr.Handler(func(r *http.Request) result.R[string] {
+		q := result.Wrap(httpr.Query("q")(r))
+		return result.Map(q, strings.ToUpper)
+	}, nil)
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "?q=abc")
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	var s string
+	err = json.NewDecoder(resp.Body).Decode(&s)
+	resp.Body.Close()
+	if err != nil || s != "ABC" {
+		t.Fatalf("got (%q, %v), want (%q, nil)", s, err, "ABC")
+	}
+
+	resp, err = http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("got status %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	cases := []struct {
+		name           string
+		status         int
+		expectedStatus int
+	}{
+		{"valid", http.StatusConflict, http.StatusConflict},
+		{"zero", 0, http.StatusInternalServerError},
+		{"too small", 42, http.StatusInternalServerError},
+		{"too large", 600, http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			httpr.WriteProblem(rec, &httpr.Problem{Title: "p", Status: tc.status})
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("got status %d, want %d", rec.Code, tc.expectedStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package httpr
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Problem is an RFC 9457 problem details object. It implements `error` so
+// that handlers can return fully specified problems.
+type Problem struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Status   int    `json:"status,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return p.Title + ": " + p.Detail
+	}
+	return p.Title
+}
+
+// StatusCode implements `StatusCoder`.
+func (p *Problem) StatusCode() int {
+	return p.Status
+}
+
+// ProblemFor builds the problem details for `err` responding to `r`. If `err`
+// wraps a `*Problem`, that is used. Otherwise, the status code is determined
+// by `c` and the error message is only exposed for client errors (4xx) so
+// that internal details don't leak.
+func ProblemFor(c *Classifier, r *http.Request, err error) *Problem {
+	var p *Problem
+	if errors.As(err, &p) {
+		cp := *p
+		if cp.Status == 0 {
+			// Don't ask the problem itself via `StatusCoder`; its status
+			// is what's missing.
+			status, ok := c.match(err)
+			if !ok {
+				status = http.StatusInternalServerError
+			}
+			cp.Status = status
+		}
+		return &cp
+	}
+	status := c.Status(err)
+	p = &Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Instance: r.URL.Path,
+	}
+	if status < 500 {
+		p.Detail = err.Error()
+	}
+	return p
+}
+
+// WriteProblem renders `p` as `application/problem+json`. If `p` has no valid
+// status code, the response has status 500.
+func WriteProblem(w http.ResponseWriter, p *Problem) {
+	status := p.Status
+	if !validStatus(status) {
+		status = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(p)
+}
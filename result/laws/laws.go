@@ -0,0 +1,152 @@
+// Package laws checks that operations on `result.R[T]` and result functions
+// obey the functor and monad laws. It checks the combinators of packages
+// result and then but can be pointed at any combinators with the same shape,
+// e.g. ones built on top of those packages.
+//
+// Laws are properties over randomly generated values and functions. They can
+// be checked with package testing/quick via `Check` or with Go fuzzing via
+// `Fuzz`:
+//
+//	func TestLaws(t *testing.T) {
+//		laws.Check(t, laws.Result(laws.Config[int]{Gen: genInt}, laws.Ops[int]{}), nil)
+//	}
+package laws
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/kdungs/go-result/result"
+)
+
+// Gen generates random values of type T.
+type Gen[T any] func(r *rand.Rand) T
+
+// Config describes the values laws are checked with.
+type Config[T any] struct {
+	// Gen generates values. It is required.
+	Gen Gen[T]
+	// Eq decides whether two values are equal. If nil, `reflect.DeepEqual` is
+	// used.
+	Eq func(a, b T) bool
+}
+
+func (c Config[T]) eq(a, b T) bool {
+	if c.Eq == nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return c.Eq(a, b)
+}
+
+// eqR decides whether two results are equal: either both hold the same error
+// or both hold equal values.
+func (c Config[T]) eqR(a, b result.R[T]) bool {
+	va, erra := a.Unwrap()
+	vb, errb := b.Unwrap()
+	if erra != nil || errb != nil {
+		return erra == errb
+	}
+	return c.eq(va, vb)
+}
+
+// genR generates a result that holds an error in about one of four cases.
+func (c Config[T]) genR(r *rand.Rand) result.R[T] {
+	if r.Intn(4) == 0 {
+		return result.OfErr[T](fmt.Errorf("error %d", r.Int()))
+	}
+	return result.Of(c.Gen(r))
+}
+
+// genF generates a pure function. Its return value is derived from its
+// argument, so that calling it twice with equal arguments yields equal
+// values.
+func (c Config[T]) genF(r *rand.Rand) func(T) T {
+	seed := r.Int63()
+	return func(x T) T {
+		return c.Gen(rand.New(rand.NewSource(seed ^ hash(x))))
+	}
+}
+
+// genF2 generates a pure binary function, see `genF`.
+func (c Config[T]) genF2(r *rand.Rand) func(T, T) T {
+	seed := r.Int63()
+	return func(x, y T) T {
+		return c.Gen(rand.New(rand.NewSource(seed ^ hash(x) ^ 31*hash(y))))
+	}
+}
+
+// genFR generates a pure function returning a result. Like `genF`, but some
+// arguments map to a fixed error.
+func (c Config[T]) genFR(r *rand.Rand) func(T) result.R[T] {
+	f := c.genF(r)
+	seed := r.Int63()
+	err := fmt.Errorf("error %d", seed)
+	return func(x T) result.R[T] {
+		if (seed^hash(x))%3 == 0 {
+			return result.OfErr[T](err)
+		}
+		return result.Of(f(x))
+	}
+}
+
+func hash(v any) int64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%#v", v)
+	return int64(h.Sum64())
+}
+
+// Law is a property that is checked against randomly generated values.
+type Law struct {
+	Name string
+	// Holds checks the law for values generated from `r`. It returns an error
+	// describing the counterexample if the law does not hold.
+	Holds func(r *rand.Rand) error
+}
+
+// violation describes a counterexample.
+func violation(lhs, rhs any) error {
+	return fmt.Errorf("%v != %v", lhs, rhs)
+}
+
+// Check checks every law in a subtest of its own using `quick.Check` with
+// `cfg`, which may be nil.
+func Check(t *testing.T, laws []Law, cfg *quick.Config) {
+	t.Helper()
+	for _, l := range laws {
+		l := l
+		t.Run(l.Name, func(t *testing.T) {
+			var last error
+			err := quick.Check(func(seed int64) bool {
+				last = l.Holds(rand.New(rand.NewSource(seed)))
+				return last == nil
+			}, cfg)
+			var cerr *quick.CheckError
+			if errors.As(err, &cerr) {
+				t.Fatalf("law does not hold (#%d, seed %v): %v", cerr.Count, cerr.In[0], last)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// Fuzz registers a fuzz target that checks all laws for seeds provided by the
+// fuzzing engine.
+func Fuzz(f *testing.F, laws []Law) {
+	for seed := int64(0); seed < 8; seed++ {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		for _, l := range laws {
+			if err := l.Holds(rand.New(rand.NewSource(seed))); err != nil {
+				t.Fatalf("%s does not hold: %v", l.Name, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package laws_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/kdungs/go-result/result"
+	"github.com/kdungs/go-result/result/laws"
+	"github.com/kdungs/go-result/then"
+)
+
+var ints = laws.Config[int]{Gen: func(r *rand.Rand) int { return r.Intn(100) }}
+
+var strs = laws.Config[string]{Gen: func(r *rand.Rand) string {
+	return strings.Repeat("ab", r.Intn(4))
+}}
+
+func TestResult(t *testing.T) {
+	laws.Check(t, laws.Result(ints, laws.Ops[int]{}), nil)
+	laws.Check(t, laws.Result(strs, laws.Ops[string]{}), nil)
+}
+
+func TestThen(t *testing.T) {
+	laws.Check(t, laws.Then(ints, laws.ThenOps[int]{}), nil)
+	laws.Check(t, laws.Then(strs, laws.ThenOps[string]{}), nil)
+}
+
+func TestViolations(t *testing.T) {
+	// A `Map` that doesn't forward errors violates the functor laws.
+	brokenMap := func(r result.R[int], f func(int) int) result.R[int] {
+		v, _ := r.Unwrap()
+		return result.Of(f(v))
+	}
+	// A `Chain` that swaps its arguments is not associative with respect to
+	// errors and order.
+	brokenChain := func(f, g then.FN[int, int]) then.FN[int, int] {
+		return then.Chain(g, f)
+	}
+	cases := []struct {
+		name string
+		laws []laws.Law
+		law  string
+	}{
+		{
+			name: "map",
+			laws: laws.Result(ints, laws.Ops[int]{Map: brokenMap}),
+			law:  "functor identity",
+		},
+		{
+			name: "chain",
+			laws: laws.Then(ints, laws.ThenOps[int]{Chain: brokenChain}),
+			law:  "lift composition",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			for _, l := range tc.laws {
+				if l.Name != tc.law {
+					continue
+				}
+				r := rand.New(rand.NewSource(1))
+				for i := 0; i < 100; i++ {
+					if l.Holds(r) != nil {
+						return
+					}
+				}
+				t.Fatalf("got no violation of %q, want one", tc.law)
+			}
+			t.Fatalf("no law named %q", tc.law)
+		})
+	}
+}
+
+func FuzzResult(f *testing.F) {
+	laws.Fuzz(f, append(laws.Result(ints, laws.Ops[int]{}), laws.Then(ints, laws.ThenOps[int]{})...))
+}
@@ -0,0 +1,103 @@
+package laws
+
+import (
+	"math/rand"
+
+	"github.com/kdungs/go-result/result"
+)
+
+// Ops are the operations on `result.R[T]` whose laws are checked. Fields that
+// are nil default to the corresponding functions of package result.
+type Ops[T any] struct {
+	Of   func(T) result.R[T]
+	Map  func(result.R[T], func(T) T) result.R[T]
+	MapR func(result.R[T], func(T) result.R[T]) result.R[T]
+	Zip  func(result.R[T], result.R[T], func(T, T) T) result.R[T]
+}
+
+func (o Ops[T]) withDefaults() Ops[T] {
+	if o.Of == nil {
+		o.Of = result.Of[T]
+	}
+	if o.Map == nil {
+		o.Map = result.Map[T, T]
+	}
+	if o.MapR == nil {
+		o.MapR = result.MapR[T, T]
+	}
+	if o.Zip == nil {
+		o.Zip = result.Zip[T, T, T]
+	}
+	return o
+}
+
+// Result returns the functor and monad laws for `ops` as well as laws
+// relating `Zip` to `MapR`.
+func Result[T any](cfg Config[T], ops Ops[T]) []Law {
+	o := ops.withDefaults()
+	check := func(lhs, rhs result.R[T]) error {
+		if !cfg.eqR(lhs, rhs) {
+			return violation(lhs, rhs)
+		}
+		return nil
+	}
+	return []Law{
+		{
+			Name: "functor identity",
+			Holds: func(r *rand.Rand) error {
+				m := cfg.genR(r)
+				return check(o.Map(m, func(x T) T { return x }), m)
+			},
+		},
+		{
+			Name: "functor composition",
+			Holds: func(r *rand.Rand) error {
+				m, f, g := cfg.genR(r), cfg.genF(r), cfg.genF(r)
+				return check(o.Map(m, func(x T) T { return g(f(x)) }), o.Map(o.Map(m, f), g))
+			},
+		},
+		{
+			Name: "monad left identity",
+			Holds: func(r *rand.Rand) error {
+				a, f := cfg.Gen(r), cfg.genFR(r)
+				return check(o.MapR(o.Of(a), f), f(a))
+			},
+		},
+		{
+			Name: "monad right identity",
+			Holds: func(r *rand.Rand) error {
+				m := cfg.genR(r)
+				return check(o.MapR(m, o.Of), m)
+			},
+		},
+		{
+			Name: "monad associativity",
+			Holds: func(r *rand.Rand) error {
+				m, f, g := cfg.genR(r), cfg.genFR(r), cfg.genFR(r)
+				return check(
+					o.MapR(o.MapR(m, f), g),
+					o.MapR(m, func(x T) result.R[T] { return o.MapR(f(x), g) }),
+				)
+			},
+		},
+		{
+			Name: "map is bind of of",
+			Holds: func(r *rand.Rand) error {
+				m, f := cfg.genR(r), cfg.genF(r)
+				return check(o.Map(m, f), o.MapR(m, func(x T) result.R[T] { return o.Of(f(x)) }))
+			},
+		},
+		{
+			Name: "zip is nested bind",
+			Holds: func(r *rand.Rand) error {
+				ma, mb, with := cfg.genR(r), cfg.genR(r), cfg.genF2(r)
+				return check(
+					o.Zip(ma, mb, with),
+					o.MapR(ma, func(a T) result.R[T] {
+						return o.Map(mb, func(b T) T { return with(a, b) })
+					}),
+				)
+			},
+		},
+	}
+}
@@ -0,0 +1,85 @@
+package laws
+
+import (
+	"math/rand"
+
+	"github.com/kdungs/go-result/result"
+	"github.com/kdungs/go-result/then"
+)
+
+// ThenOps are the operations on result functions whose laws are checked.
+// Fields that are nil default to the corresponding functions of package then.
+type ThenOps[T any] struct {
+	Chain func(then.FN[T, T], then.FN[T, T]) then.FN[T, T]
+	Lift  func(then.F[T, T]) then.FN[T, T]
+	Map   func(then.FN[T, T], then.F[T, T]) then.FN[T, T]
+}
+
+func (o ThenOps[T]) withDefaults() ThenOps[T] {
+	if o.Chain == nil {
+		o.Chain = then.Chain[T, T, T]
+	}
+	if o.Lift == nil {
+		o.Lift = then.Lift[T, T]
+	}
+	if o.Map == nil {
+		o.Map = then.Map[T, T, T]
+	}
+	return o
+}
+
+// Then returns the category laws for `Chain` with `Lift` as the identity as
+// well as laws relating `Lift` and `Map` to `Chain`. Result functions are
+// compared by applying them to a generated value.
+func Then[T any](cfg Config[T], ops ThenOps[T]) []Law {
+	o := ops.withDefaults()
+	genFN := func(r *rand.Rand) then.FN[T, T] {
+		f := cfg.genFR(r)
+		return func(x T) (T, error) { return f(x).Unwrap() }
+	}
+	check := func(r *rand.Rand, lhs, rhs then.FN[T, T]) error {
+		a := cfg.Gen(r)
+		if l, r := result.Wrap(lhs(a)), result.Wrap(rhs(a)); !cfg.eqR(l, r) {
+			return violation(l, r)
+		}
+		return nil
+	}
+	id := o.Lift(func(x T) T { return x })
+	return []Law{
+		{
+			Name: "chain left identity",
+			Holds: func(r *rand.Rand) error {
+				f := genFN(r)
+				return check(r, o.Chain(id, f), f)
+			},
+		},
+		{
+			Name: "chain right identity",
+			Holds: func(r *rand.Rand) error {
+				f := genFN(r)
+				return check(r, o.Chain(f, id), f)
+			},
+		},
+		{
+			Name: "chain associativity",
+			Holds: func(r *rand.Rand) error {
+				f, g, h := genFN(r), genFN(r), genFN(r)
+				return check(r, o.Chain(o.Chain(f, g), h), o.Chain(f, o.Chain(g, h)))
+			},
+		},
+		{
+			Name: "lift composition",
+			Holds: func(r *rand.Rand) error {
+				f, g := cfg.genF(r), cfg.genF(r)
+				return check(r, o.Lift(func(x T) T { return g(f(x)) }), o.Chain(o.Lift(f), o.Lift(g)))
+			},
+		},
+		{
+			Name: "map is chain of lift",
+			Holds: func(r *rand.Rand) error {
+				f, g := genFN(r), cfg.genF(r)
+				return check(r, o.Map(f, g), o.Chain(f, o.Lift(g)))
+			},
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package result
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Lazy is a result that is computed at most once, on first use. It is safe
+// for concurrent use; concurrent callers of `Get` wait for the computation in
+// progress.
+type Lazy[T any] struct {
+	retry bool
+	get   func() R[T]
+}
+
+// NewLazy returns a `Lazy[T]` that calls `f` on the first call to `Get` and
+// returns the same result, value or error, from then on. If `f` panics, every
+// call to `Get` panics with the same value.
+func NewLazy[T any](f func() (T, error)) *Lazy[T] {
+	g := sync.OnceValues(f)
+	return &Lazy[T]{get: func() R[T] { return Wrap(g()) }}
+}
+
+// NewLazyRetry returns a `Lazy[T]` that only keeps values: if `f` returns an
+// error (or panics), the next call to `Get` calls `f` again.
+func NewLazyRetry[T any](f func() (T, error)) *Lazy[T] {
+	var (
+		mu  sync.Mutex
+		res atomic.Pointer[R[T]]
+	)
+	return &Lazy[T]{retry: true, get: func() R[T] {
+		if r := res.Load(); r != nil {
+			return *r
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if r := res.Load(); r != nil {
+			return *r
+		}
+		r := Wrap(f())
+		if r.err == nil {
+			res.Store(&r)
+		}
+		return r
+	}}
+}
+
+// Get returns the result, computing it if necessary.
+func (l *Lazy[T]) Get() R[T] {
+	return l.get()
+}
+
+// LazyMap returns a `Lazy[B]` that applies `f` to the value of `l` when it is
+// first used, without forcing `l` before that. It retries errors if `l` does.
+func LazyMap[A, B any](l *Lazy[A], f func(A) B) *Lazy[B] {
+	return newLazy(l.retry, func() (B, error) {
+		return Map(l.Get(), f).Unwrap()
+	})
+}
+
+// LazyMapR is `LazyMap` for functions returning a result.
+func LazyMapR[A, B any](l *Lazy[A], f func(A) R[B]) *Lazy[B] {
+	return newLazy(l.retry, func() (B, error) {
+		return MapR(l.Get(), f).Unwrap()
+	})
+}
+
+func newLazy[T any](retry bool, f func() (T, error)) *Lazy[T] {
+	if retry {
+		return NewLazyRetry(f)
+	}
+	return NewLazy(f)
+}
@@ -0,0 +1,145 @@
+package result_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kdungs/go-result/result"
+)
+
+// flaky returns a function that fails the first `failures` times it is
+// called and counts its calls in `calls`.
+func flaky(calls *atomic.Int32, failures int32) func() (int, error) {
+	return func() (int, error) {
+		if n := calls.Add(1); n <= failures {
+			return 0, errors.New("flaky")
+		}
+		return 42, nil
+	}
+}
+
+func TestLazy(t *testing.T) {
+	cases := []struct {
+		name          string
+		newLazy       func(func() (int, error)) *result.Lazy[int]
+		expectedErrs  int
+		expectedCalls int32
+	}{
+		{
+			name:          "cached",
+			newLazy:       result.NewLazy[int],
+			expectedErrs:  3,
+			expectedCalls: 1,
+		},
+		{
+			name:          "retry",
+			newLazy:       result.NewLazyRetry[int],
+			expectedErrs:  1,
+			expectedCalls: 2,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var calls, mapped atomic.Int32
+			l := tc.newLazy(flaky(&calls, 1))
+			m := result.LazyMap(l, func(x int) int {
+				mapped.Add(1)
+				return x + 1
+			})
+			if n := calls.Load(); n != 0 {
+				t.Fatalf("want 0 calls before Get, got %d", n)
+			}
+			errs := 0
+			for range 3 {
+				if _, err := m.Get().Unwrap(); err != nil {
+					errs++
+				}
+			}
+			if errs != tc.expectedErrs {
+				t.Fatalf("want %d errors, got %d", tc.expectedErrs, errs)
+			}
+			if n := calls.Load(); n != tc.expectedCalls {
+				t.Fatalf("want %d calls, got %d", tc.expectedCalls, n)
+			}
+			if tc.expectedErrs == 3 {
+				return
+			}
+			v, err := m.Get().Unwrap()
+			if err != nil {
+				t.Fatalf("want no error, got %v", err)
+			}
+			if v != 43 {
+				t.Fatalf("want 43, got %d", v)
+			}
+			if n := mapped.Load(); n != 1 {
+				t.Fatalf("want 1 call of f, got %d", n)
+			}
+		})
+	}
+}
+
+func TestLazyConcurrent(t *testing.T) {
+	cases := []struct {
+		name    string
+		newLazy func(func() (int, error)) *result.Lazy[int]
+	}{
+		{
+			name:    "cached",
+			newLazy: result.NewLazy[int],
+		},
+		{
+			name:    "retry",
+			newLazy: result.NewLazyRetry[int],
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+			l := result.LazyMapR(tc.newLazy(flaky(&calls, 0)), func(x int) result.R[int] { return result.Of(2 * x) })
+			var wg sync.WaitGroup
+			for range 16 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if v, err := l.Get().Unwrap(); err != nil || v != 84 {
+						t.Errorf("want (84, nil), got (%d, %v)", v, err)
+					}
+				}()
+			}
+			wg.Wait()
+			if n := calls.Load(); n != 1 {
+				t.Fatalf("want 1 call, got %d", n)
+			}
+		})
+	}
+}
+
+func TestLazyRetryPanic(t *testing.T) {
+	panicked := false
+	l := result.NewLazyRetry(func() (int, error) {
+		if !panicked {
+			panicked = true
+			panic("boom")
+		}
+		return 1, nil
+	})
+	func() {
+		defer func() {
+			if p := recover(); p != "boom" {
+				t.Fatalf("want panic %q, got %v", "boom", p)
+			}
+		}()
+		l.Get()
+	}()
+	v, err := l.Get().Unwrap()
+	if err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+	if v != 1 {
+		t.Fatalf("want 1, got %d", v)
+	}
+}
@@ -0,0 +1,101 @@
+package result
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrPromiseSet is returned when setting a `Promise` that is already set.
+var ErrPromiseSet = errors.New("result: promise already set")
+
+// Promise holds a result that is set exactly once, typically by one
+// goroutine, and waited for by others. The zero value is an unset promise
+// ready to use. A Promise must not be copied after first use.
+type Promise[T any] struct {
+	mu   sync.Mutex
+	done chan struct{}
+	set  bool
+	r    R[T]
+	subs []func(R[T])
+}
+
+// ch returns the done channel, creating it if necessary. It must be called
+// with `p.mu` held.
+func (p *Promise[T]) ch() chan struct{} {
+	if p.done == nil {
+		p.done = make(chan struct{})
+	}
+	return p.done
+}
+
+// Set sets the result of `p` to `r`, wakes up all waiters and calls all
+// subscribers in the order they subscribed. It returns `ErrPromiseSet` if `p`
+// was set before, in which case `r` is discarded.
+func (p *Promise[T]) Set(r R[T]) error {
+	p.mu.Lock()
+	if p.set {
+		p.mu.Unlock()
+		return ErrPromiseSet
+	}
+	p.set, p.r = true, r
+	close(p.ch())
+	subs := p.subs
+	p.subs = nil
+	p.mu.Unlock()
+	for _, f := range subs {
+		f(r)
+	}
+	return nil
+}
+
+// Resolve sets the result of `p` to the value `v`. See `Set`.
+func (p *Promise[T]) Resolve(v T) error {
+	return p.Set(Of(v))
+}
+
+// Reject sets the result of `p` to the error `err`. See `Set`.
+func (p *Promise[T]) Reject(err error) error {
+	return p.Set(OfErr[T](err))
+}
+
+// Done returns a channel that is closed once `p` is set.
+func (p *Promise[T]) Done() <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.ch()
+}
+
+// Peek returns the result of `p` and true if it is set, without waiting.
+func (p *Promise[T]) Peek() (R[T], bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.r, p.set
+}
+
+// Wait waits until `p` is set and returns its result. If `ctx` is done
+// first, it returns the context's error instead.
+func (p *Promise[T]) Wait(ctx context.Context) R[T] {
+	select {
+	case <-p.Done():
+		r, _ := p.Peek()
+		return r
+	case <-ctx.Done():
+		return OfErr[T](ctx.Err())
+	}
+}
+
+// Subscribe registers `f` to be called with the result of `p` once it is set.
+// Subscribers are called in the goroutine that sets `p`; if `p` is already
+// set, `f` is called right away in the calling goroutine.
+func (p *Promise[T]) Subscribe(f func(R[T])) {
+	p.mu.Lock()
+	if !p.set {
+		p.subs = append(p.subs, f)
+		p.mu.Unlock()
+		return
+	}
+	r := p.r
+	p.mu.Unlock()
+	f(r)
+}
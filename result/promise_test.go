@@ -0,0 +1,136 @@
+package result_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kdungs/go-result/result"
+)
+
+func TestPromise(t *testing.T) {
+	errP := errors.New("p")
+	cases := []struct {
+		name        string
+		set         func(p *result.Promise[int]) error
+		expectedErr error
+		expectedVal int
+	}{
+		{
+			name:        "resolve",
+			set:         func(p *result.Promise[int]) error { return p.Resolve(42) },
+			expectedErr: nil,
+			expectedVal: 42,
+		},
+		{
+			name:        "reject",
+			set:         func(p *result.Promise[int]) error { return p.Reject(errP) },
+			expectedErr: errP,
+		},
+		{
+			name:        "set",
+			set:         func(p *result.Promise[int]) error { return p.Set(result.Of(7)) },
+			expectedErr: nil,
+			expectedVal: 7,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var p result.Promise[int]
+			if r, ok := p.Peek(); ok {
+				t.Fatalf("want no result, got %v", r)
+			}
+			var before []result.R[int]
+			p.Subscribe(func(r result.R[int]) { before = append(before, r) })
+
+			if err := tc.set(&p); err != nil {
+				t.Fatalf("want no error, got %v", err)
+			}
+			// Later attempts neither fail loudly nor change the result.
+			if err := p.Resolve(-1); err != result.ErrPromiseSet {
+				t.Fatalf("want %v, got %v", result.ErrPromiseSet, err)
+			}
+			if err := p.Reject(errors.New("late")); err != result.ErrPromiseSet {
+				t.Fatalf("want %v, got %v", result.ErrPromiseSet, err)
+			}
+
+			select {
+			case <-p.Done():
+			default:
+				t.Fatalf("want Done to be closed")
+			}
+			var after []result.R[int]
+			p.Subscribe(func(r result.R[int]) { after = append(after, r) })
+			if len(before) != 1 || len(after) != 1 {
+				t.Fatalf("want 1 call of each subscriber, got %d and %d", len(before), len(after))
+			}
+			for _, r := range []result.R[int]{p.Wait(context.Background()), before[0], after[0]} {
+				v, err := r.Unwrap()
+				if err != tc.expectedErr {
+					t.Fatalf("want %v, got %v", tc.expectedErr, err)
+				}
+				if tc.expectedErr == nil && v != tc.expectedVal {
+					t.Fatalf("want %d, got %d", tc.expectedVal, v)
+				}
+			}
+		})
+	}
+}
+
+func TestPromiseWaitCanceled(t *testing.T) {
+	var p result.Promise[string]
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.Wait(ctx).Unwrap(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("want %v, got %v", context.Canceled, err)
+	}
+	if err := p.Resolve("still settable"); err != nil {
+		t.Fatalf("want no error, got %v", err)
+	}
+}
+
+func TestPromiseConcurrent(t *testing.T) {
+	const n = 32
+	var (
+		p        result.Promise[int]
+		wg       sync.WaitGroup
+		wins     atomic.Int32
+		notified atomic.Int32
+	)
+	for i := range n {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			if p.Resolve(i) == nil {
+				wins.Add(1)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			p.Subscribe(func(result.R[int]) { notified.Add(1) })
+		}()
+		go func() {
+			defer wg.Done()
+			<-p.Done()
+			if _, err := p.Wait(context.Background()).Unwrap(); err != nil {
+				t.Errorf("want no error, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if w := wins.Load(); w != 1 {
+		t.Fatalf("want 1 successful set, got %d", w)
+	}
+	if got := notified.Load(); got != n {
+		t.Fatalf("want %d notifications, got %d", n, got)
+	}
+	r, _ := p.Peek()
+	for range 3 {
+		if got := p.Wait(context.Background()); got != r {
+			t.Fatalf("want %v, got %v", r, got)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package resulttest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Diff reports the differences between `got` and `want`, one per line, each
+// prefixed with its path, e.g. `.Items[2].Name: -"a" +"b"`. It returns the
+// empty string if both are deeply equal.
+func Diff(got, want any) string {
+	d := &differ{seen: make(map[[2]uintptr]bool)}
+	d.diff("", reflect.ValueOf(got), reflect.ValueOf(want))
+	return strings.Join(d.lines, "\n")
+}
+
+type differ struct {
+	lines []string
+	// seen holds pairs of pointers that are already being compared so that
+	// cyclic data structures terminate.
+	seen map[[2]uintptr]bool
+}
+
+func (d *differ) add(path, msg string, args ...any) {
+	if path == "" {
+		path = "value"
+	}
+	d.lines = append(d.lines, path+": "+fmt.Sprintf(msg, args...))
+}
+
+func (d *differ) diff(path string, got, want reflect.Value) {
+	report := func() {
+		d.add(path, "-%s +%s", format(got), format(want))
+	}
+	if !got.IsValid() || !want.IsValid() {
+		if got.IsValid() != want.IsValid() {
+			report()
+		}
+		return
+	}
+	if got.Type() != want.Type() {
+		report()
+		return
+	}
+	switch got.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if got.IsNil() || want.IsNil() {
+			if got.IsNil() != want.IsNil() {
+				report()
+			}
+			return
+		}
+		if got.Kind() == reflect.Pointer {
+			k := [2]uintptr{got.Pointer(), want.Pointer()}
+			if d.seen[k] {
+				return
+			}
+			d.seen[k] = true
+		}
+		d.diff(path, got.Elem(), want.Elem())
+	case reflect.Struct:
+		for i := 0; i < got.NumField(); i++ {
+			d.diff(path+"."+got.Type().Field(i).Name, got.Field(i), want.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		if got.Kind() == reflect.Slice && got.IsNil() != want.IsNil() {
+			report()
+			return
+		}
+		n := max(got.Len(), want.Len())
+		for i := 0; i < n; i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= got.Len():
+				d.add(p, "+%s", format(want.Index(i)))
+			case i >= want.Len():
+				d.add(p, "-%s", format(got.Index(i)))
+			default:
+				d.diff(p, got.Index(i), want.Index(i))
+			}
+		}
+	case reflect.Map:
+		if got.IsNil() != want.IsNil() {
+			report()
+			return
+		}
+		keys := got.MapKeys()
+		for _, k := range want.MapKeys() {
+			if !got.MapIndex(k).IsValid() {
+				keys = append(keys, k)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return format(keys[i]) < format(keys[j]) })
+		for _, k := range keys {
+			p := fmt.Sprintf("%s[%s]", path, format(k))
+			g, w := got.MapIndex(k), want.MapIndex(k)
+			switch {
+			case !g.IsValid():
+				d.add(p, "+%s", format(w))
+			case !w.IsValid():
+				d.add(p, "-%s", format(g))
+			default:
+				d.diff(p, g, w)
+			}
+		}
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if got.Pointer() != want.Pointer() {
+			report()
+		}
+	default:
+		if !got.Equal(want) {
+			report()
+		}
+	}
+}
+
+func format(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%#v", v)
+}
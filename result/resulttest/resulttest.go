@@ -0,0 +1,62 @@
+// Package resulttest provides assertions for tests of code using
+// `result.R[T]`.
+package resulttest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kdungs/go-result/result"
+)
+
+// RequireOk fails the test if `r` holds an error and returns its value
+// otherwise.
+func RequireOk[T any](t testing.TB, r result.R[T]) T {
+	t.Helper()
+	v, err := r.Unwrap()
+	if err != nil {
+		t.Fatalf("got error %v, want value", err)
+	}
+	return v
+}
+
+// RequireErr fails the test if `r` holds a value and returns its error
+// otherwise.
+func RequireErr[T any](t testing.TB, r result.R[T]) error {
+	t.Helper()
+	v, err := r.Unwrap()
+	if err == nil {
+		t.Fatalf("got value %v, want error", v)
+	}
+	return err
+}
+
+// RequireErrIs fails the test unless `r` holds an error matching `target`
+// according to `errors.Is`.
+func RequireErrIs[T any](t testing.TB, r result.R[T], target error) {
+	t.Helper()
+	if err := RequireErr(t, r); !errors.Is(err, target) {
+		t.Fatalf("got error %v, want %v", err, target)
+	}
+}
+
+// RequireErrAs fails the test unless `r` holds an error for which `errors.As`
+// finds an `E`, which is returned. Since `T` can be inferred, calling code
+// usually only provides `E`, e.g. `RequireErrAs[*fs.PathError](t, r)`.
+func RequireErrAs[E error, T any](t testing.TB, r result.R[T]) E {
+	t.Helper()
+	var target E
+	if err := RequireErr(t, r); !errors.As(err, &target) {
+		t.Fatalf("got error %v, want %T", err, target)
+	}
+	return target
+}
+
+// RequireEqual fails the test unless `r` holds a value deeply equal to `want`.
+// The failure message contains the differences as reported by `Diff`.
+func RequireEqual[T any](t testing.TB, r result.R[T], want T) {
+	t.Helper()
+	if d := Diff(RequireOk(t, r), want); d != "" {
+		t.Fatalf("value differs (-got +want):\n%s", d)
+	}
+}
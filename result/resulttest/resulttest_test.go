@@ -0,0 +1,185 @@
+package resulttest_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kdungs/go-result/result"
+	"github.com/kdungs/go-result/result/resulttest"
+)
+
+// fakeT records fatal failures instead of failing the test.
+type fakeT struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Fatalf(format string, args ...any) {
+	t.failed = true
+	t.msg = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+// run calls `f` with a `fakeT` on its own goroutine so that `Fatalf` can stop
+// it like it would stop a test.
+func run(f func(t testing.TB)) *fakeT {
+	ft := &fakeT{}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		f(ft)
+	}()
+	wg.Wait()
+	return ft
+}
+
+func TestRequire(t *testing.T) {
+	errV := errors.New("v")
+	pathErr := &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}
+	cases := []struct {
+		name   string
+		f      func(t testing.TB)
+		failed bool
+	}{
+		{
+			name: "ok with value",
+			f: func(t testing.TB) {
+				if v := resulttest.RequireOk(t, result.Of(42)); v != 42 {
+					panic("unexpected value")
+				}
+			},
+		},
+		{
+			name:   "ok with error",
+			f:      func(t testing.TB) { resulttest.RequireOk(t, result.OfErr[int](errV)) },
+			failed: true,
+		},
+		{
+			name: "is",
+			f: func(t testing.TB) {
+				resulttest.RequireErrIs(t, result.OfErr[int](fmt.Errorf("w: %w", errV)), errV)
+			},
+		},
+		{
+			name:   "is with other error",
+			f:      func(t testing.TB) { resulttest.RequireErrIs(t, result.OfErr[int](errors.New("x")), errV) },
+			failed: true,
+		},
+		{
+			name:   "is with value",
+			f:      func(t testing.TB) { resulttest.RequireErrIs(t, result.Of(42), errV) },
+			failed: true,
+		},
+		{
+			name: "as",
+			f: func(t testing.TB) {
+				if e := resulttest.RequireErrAs[*fs.PathError](t, result.OfErr[int](pathErr)); e != pathErr {
+					panic("unexpected error")
+				}
+			},
+		},
+		{
+			name:   "as with other error",
+			f:      func(t testing.TB) { resulttest.RequireErrAs[*fs.PathError](t, result.OfErr[int](errV)) },
+			failed: true,
+		},
+		{
+			name: "equal",
+			f:    func(t testing.TB) { resulttest.RequireEqual(t, result.Of([]int{1, 2}), []int{1, 2}) },
+		},
+		{
+			name:   "equal with other value",
+			f:      func(t testing.TB) { resulttest.RequireEqual(t, result.Of([]int{1, 2}), []int{1, 3}) },
+			failed: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if ft := run(tc.f); ft.failed != tc.failed {
+				t.Fatalf("got failed=%v (%q), want %v", ft.failed, ft.msg, tc.failed)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	type item struct {
+		Name string
+		tags map[string]int
+	}
+	type node struct {
+		Items []item
+		Next  *node
+	}
+	cyclic := &node{}
+	cyclic.Next = cyclic
+	cases := []struct {
+		name     string
+		got      any
+		want     any
+		expected []string
+	}{
+		{
+			name: "equal",
+			got:  node{Items: []item{{"a", map[string]int{"x": 1}}}},
+			want: node{Items: []item{{"a", map[string]int{"x": 1}}}},
+		},
+		{
+			name:     "field",
+			got:      node{Items: []item{{Name: "a"}}},
+			want:     node{Items: []item{{Name: "b"}}},
+			expected: []string{`.Items[0].Name: -"a" +"b"`},
+		},
+		{
+			name:     "length",
+			got:      []int{1},
+			want:     []int{1, 2},
+			expected: []string{`[1]: +2`},
+		},
+		{
+			name: "map",
+			got:  item{tags: map[string]int{"x": 1, "y": 2}},
+			want: item{tags: map[string]int{"x": 2, "z": 3}},
+			expected: []string{
+				`.tags["x"]: -1 +2`,
+				`.tags["y"]: -2`,
+				`.tags["z"]: +3`,
+			},
+		},
+		{
+			name:     "nil pointer",
+			got:      &node{},
+			want:     &node{Next: &node{}},
+			expected: []string{`.Next: -(*resulttest_test.node)(nil) +&resulttest_test.node{Items:[]resulttest_test.item(nil), Next:(*resulttest_test.node)(nil)}`},
+		},
+		{
+			name:     "types",
+			got:      1,
+			want:     "1",
+			expected: []string{`value: -1 +"1"`},
+		},
+		{
+			name: "cycle",
+			got:  cyclic,
+			want: cyclic,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if d := resulttest.Diff(tc.got, tc.want); d != strings.Join(tc.expected, "\n") {
+				t.Fatalf("got\n%s\nwant\n%s", d, strings.Join(tc.expected, "\n"))
+			}
+		})
+	}
+}
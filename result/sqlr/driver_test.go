@@ -0,0 +1,102 @@
+package sqlr_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+)
+
+// fakeDB is an in-memory `driver.Connector` that records what happens to its
+// transactions and serves a fixed set of rows for every query.
+type fakeDB struct {
+	rows        [][]driver.Value
+	rowsErr     error
+	commitErr   error
+	rollbackErr error
+
+	log    []string
+	closed int
+}
+
+func (db *fakeDB) open(t *testing.T) *sql.DB {
+	t.Helper()
+	sdb := sql.OpenDB(db)
+	t.Cleanup(func() { sdb.Close() })
+	return sdb
+}
+
+func (db *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db}, nil }
+func (db *fakeDB) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, errors.New("use sql.OpenDB") }
+
+type fakeConn struct{ db *fakeDB }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return &fakeStmt{c.db}, nil }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.db.log = append(c.db.log, "begin")
+	return &fakeTx{c.db}, nil
+}
+
+type fakeTx struct{ db *fakeDB }
+
+func (tx *fakeTx) Commit() error {
+	tx.db.log = append(tx.db.log, "commit")
+	return tx.db.commitErr
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.db.log = append(tx.db.log, "rollback")
+	return tx.db.rollbackErr
+}
+
+type fakeStmt struct{ db *fakeDB }
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	s.db.log = append(s.db.log, "exec")
+	return driver.RowsAffected(1), nil
+}
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{db: s.db}, nil
+}
+
+type fakeRows struct {
+	db *fakeDB
+	i  int
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.db.rows) == 0 {
+		return []string{"v"}
+	}
+	cols := make([]string, len(r.db.rows[0]))
+	for i := range cols {
+		cols[i] = "v"
+	}
+	return cols
+}
+
+func (r *fakeRows) Close() error {
+	r.db.closed++
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.db.rows) {
+		if r.db.rowsErr != nil {
+			return r.db.rowsErr
+		}
+		return io.EOF
+	}
+	copy(dest, r.db.rows[r.i])
+	r.i++
+	return nil
+}
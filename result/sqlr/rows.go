@@ -0,0 +1,65 @@
+package sqlr
+
+import (
+	"database/sql"
+	"errors"
+	"iter"
+
+	"github.com/kdungs/go-result/result"
+)
+
+// Scanner is implemented by `*sql.Row` and `*sql.Rows`.
+type Scanner interface {
+	Scan(dest ...any) error
+}
+
+// QueryRows iterates over `rows`, turning every row into a value using `scan`.
+// Iteration stops after the first error, which is yielded as the last element.
+// This includes the error held by `rows` itself as well as errors reported by
+// `(*sql.Rows).Err` after the last row. `rows` is always closed.
+func QueryRows[T any](rows result.R[*sql.Rows], scan func(Scanner) (T, error)) iter.Seq[result.R[T]] {
+	return func(yield func(result.R[T]) bool) {
+		rs, err := rows.Unwrap()
+		if err != nil {
+			yield(result.OfErr[T](err))
+			return
+		}
+		defer rs.Close()
+		for rs.Next() {
+			r := result.Wrap(scan(rs))
+			if !yield(r) {
+				return
+			}
+			if _, err := r.Unwrap(); err != nil {
+				return
+			}
+		}
+		if err := rs.Err(); err != nil {
+			yield(result.OfErr[T](err))
+		}
+	}
+}
+
+// CollectRows is like `QueryRows` but collects all values into a slice. It
+// returns the first error encountered instead.
+func CollectRows[T any](rows result.R[*sql.Rows], scan func(Scanner) (T, error)) result.R[[]T] {
+	var vs []T
+	for r := range QueryRows(rows, scan) {
+		v, err := r.Unwrap()
+		if err != nil {
+			return result.OfErr[[]T](err)
+		}
+		vs = append(vs, v)
+	}
+	return result.Of(vs)
+}
+
+// QueryOne turns `row` into a value using `scan`. If there is no row, the
+// result holds `notFound` or, if that is nil, `sql.ErrNoRows`.
+func QueryOne[T any](row *sql.Row, scan func(Scanner) (T, error), notFound error) result.R[T] {
+	v, err := scan(row)
+	if notFound != nil && errors.Is(err, sql.ErrNoRows) {
+		err = notFound
+	}
+	return result.Wrap(v, err)
+}
@@ -0,0 +1,158 @@
+package sqlr_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/kdungs/go-result/result"
+	"github.com/kdungs/go-result/result/sqlr"
+)
+
+var errScan = errors.New("scan")
+
+func scanInt(s sqlr.Scanner) (int, error) {
+	var v any
+	if err := s.Scan(&v); err != nil {
+		return 0, err
+	}
+	i, ok := v.(int64)
+	if !ok {
+		return 0, errScan
+	}
+	return int(i), nil
+}
+
+func TestQueryRows(t *testing.T) {
+	errRows := errors.New("rows")
+	errQuery := errors.New("query")
+	cases := []struct {
+		name        string
+		db          fakeDB
+		queryErr    error
+		expected    []int
+		expectedErr error
+	}{
+		{
+			name:     "rows",
+			db:       fakeDB{rows: [][]driver.Value{{int64(1)}, {int64(2)}, {int64(3)}}},
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:        "scan error",
+			db:          fakeDB{rows: [][]driver.Value{{int64(1)}, {"nope"}, {int64(3)}}},
+			expected:    []int{1},
+			expectedErr: errScan,
+		},
+		{
+			name:        "rows error",
+			db:          fakeDB{rows: [][]driver.Value{{int64(1)}}, rowsErr: errRows},
+			expected:    []int{1},
+			expectedErr: errRows,
+		},
+		{
+			name:        "query error",
+			queryErr:    errQuery,
+			expectedErr: errQuery,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			db := tc.db.open(t)
+			rows := result.Wrap(db.Query("SELECT v FROM t"))
+			if tc.queryErr != nil {
+				rows = result.OfErr[*sql.Rows](tc.queryErr)
+			}
+			var vs []int
+			var err error
+			for r := range sqlr.QueryRows(rows, scanInt) {
+				v, rerr := r.Unwrap()
+				if rerr != nil {
+					err = rerr
+					continue
+				}
+				if err != nil {
+					t.Fatalf("got value %d after error %v", v, err)
+				}
+				vs = append(vs, v)
+			}
+			if !reflect.DeepEqual(vs, tc.expected) {
+				t.Fatalf("got %v, want %v", vs, tc.expected)
+			}
+			if err != tc.expectedErr {
+				t.Fatalf("got %v, want %v", err, tc.expectedErr)
+			}
+			if tc.queryErr == nil && tc.db.closed != 1 {
+				t.Fatalf("got %d closed rows, want 1", tc.db.closed)
+			}
+		})
+	}
+}
+
+func TestQueryRowsBreak(t *testing.T) {
+	fdb := &fakeDB{rows: [][]driver.Value{{int64(1)}, {int64(2)}}}
+	db := fdb.open(t)
+	for range sqlr.QueryRows(result.Wrap(db.Query("SELECT v FROM t")), scanInt) {
+		break
+	}
+	if fdb.closed != 1 {
+		t.Fatalf("got %d closed rows, want 1", fdb.closed)
+	}
+}
+
+func TestCollectRows(t *testing.T) {
+	errRows := errors.New("rows")
+	fdb := &fakeDB{rows: [][]driver.Value{{int64(1)}, {int64(2)}}}
+	db := fdb.open(t)
+	vs, err := sqlr.CollectRows(result.Wrap(db.Query("SELECT v FROM t")), scanInt).Unwrap()
+	if err != nil || !reflect.DeepEqual(vs, []int{1, 2}) {
+		t.Fatalf("got (%v, %v), want ([1 2], nil)", vs, err)
+	}
+	fdb.rowsErr = errRows
+	if _, err := sqlr.CollectRows(result.Wrap(db.Query("SELECT v FROM t")), scanInt).Unwrap(); err != errRows {
+		t.Fatalf("got %v, want %v", err, errRows)
+	}
+}
+
+func TestQueryOne(t *testing.T) {
+	errNotFound := errors.New("not found")
+	cases := []struct {
+		name        string
+		rows        [][]driver.Value
+		notFound    error
+		expected    int
+		expectedErr error
+	}{
+		{
+			name:     "row",
+			rows:     [][]driver.Value{{int64(23)}, {int64(42)}},
+			expected: 23,
+		},
+		{
+			name:        "no row",
+			expectedErr: sql.ErrNoRows,
+		},
+		{
+			name:        "no row mapped",
+			notFound:    errNotFound,
+			expectedErr: errNotFound,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			fdb := &fakeDB{rows: tc.rows}
+			db := fdb.open(t)
+			v, err := sqlr.QueryOne(db.QueryRow("SELECT v FROM t"), scanInt, tc.notFound).Unwrap()
+			if err != tc.expectedErr {
+				t.Fatalf("got %v, want %v", err, tc.expectedErr)
+			}
+			if err == nil && v != tc.expected {
+				t.Fatalf("got %d, want %d", v, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Package sqlr provides helpers for package database/sql that return
+// `result.R[T]` instead of `(T, error)`.
+package sqlr
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/kdungs/go-result/result"
+)
+
+// TxBeginner is implemented by `*sql.DB` and `*sql.Conn`.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// WithTx runs `f` in a transaction. The transaction is committed if `f`
+// returns a value and rolled back if it returns an error or panics. Errors
+// from rolling back are joined with the error returned by `f`; panics are
+// re-raised after rolling back.
+func WithTx[T any](ctx context.Context, db TxBeginner, f func(*sql.Tx) result.R[T]) (r result.R[T]) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return result.OfErr[T](err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+	v, err := f(tx).Unwrap()
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			err = errors.Join(err, rerr)
+		}
+		return result.OfErr[T](err)
+	}
+	if err := tx.Commit(); err != nil {
+		return result.OfErr[T](err)
+	}
+	return result.Of(v)
+}
@@ -0,0 +1,90 @@
+package sqlr_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/kdungs/go-result/result"
+	"github.com/kdungs/go-result/result/sqlr"
+)
+
+func TestWithTx(t *testing.T) {
+	errF := errors.New("f")
+	errCommit := errors.New("commit")
+	errRollback := errors.New("rollback")
+	cases := []struct {
+		name        string
+		db          fakeDB
+		r           result.R[int]
+		expectedErr []error
+		expectedLog []string
+	}{
+		{
+			name:        "value commits",
+			r:           result.Of(42),
+			expectedLog: []string{"begin", "exec", "commit"},
+		},
+		{
+			name:        "error rolls back",
+			r:           result.OfErr[int](errF),
+			expectedErr: []error{errF},
+			expectedLog: []string{"begin", "rollback"},
+		},
+		{
+			name:        "rollback error is joined",
+			db:          fakeDB{rollbackErr: errRollback},
+			r:           result.OfErr[int](errF),
+			expectedErr: []error{errF, errRollback},
+			expectedLog: []string{"begin", "rollback"},
+		},
+		{
+			name:        "commit error",
+			db:          fakeDB{commitErr: errCommit},
+			r:           result.Of(42),
+			expectedErr: []error{errCommit},
+			expectedLog: []string{"begin", "exec", "commit"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			db := tc.db.open(t)
+			v, err := sqlr.WithTx(context.Background(), db, func(tx *sql.Tx) result.R[int] {
+				return result.MapE(tc.r, func(v int) (int, error) {
+					_, err := tx.Exec("UPDATE t SET v = ?", v)
+					return v, err
+				})
+			}).Unwrap()
+			for _, e := range tc.expectedErr {
+				if !errors.Is(err, e) {
+					t.Fatalf("got %v, want it to contain %v", err, e)
+				}
+			}
+			if tc.expectedErr == nil && (err != nil || v != 42) {
+				t.Fatalf("got (%d, %v), want (42, nil)", v, err)
+			}
+			if !reflect.DeepEqual(tc.db.log, tc.expectedLog) {
+				t.Fatalf("got %v, want %v", tc.db.log, tc.expectedLog)
+			}
+		})
+	}
+}
+
+func TestWithTxPanic(t *testing.T) {
+	fdb := &fakeDB{}
+	db := fdb.open(t)
+	defer func() {
+		if p := recover(); p != "boom" {
+			t.Fatalf("got panic %v, want %q", p, "boom")
+		}
+		if expected := []string{"begin", "rollback"}; !reflect.DeepEqual(fdb.log, expected) {
+			t.Fatalf("got %v, want %v", fdb.log, expected)
+		}
+	}()
+	sqlr.WithTx(context.Background(), db, func(*sql.Tx) result.R[int] {
+		panic("boom")
+	})
+}
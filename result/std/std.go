@@ -0,0 +1,30 @@
+// Package std wraps commonly used fallible functions of the standard library.
+// Every function is available as a `then.FN`, e.g. `std.Atoi`, and as a
+// function returning a `result.R[T]`, e.g. `std.AtoiR`.
+//
+// Most of the package is generated by resultgen, which can be used to wrap
+// other packages in the same way.
+package std
+
+//go:generate go run ../cmd/resultgen -pkg std -o std_gen.go strconv.Atoi strconv.ParseBool strconv.ParseInt strconv.ParseUint strconv.ParseFloat strconv.Unquote os.ReadFile os.ReadDir os.Open os.Create os.Stat io.ReadAll encoding/json.Marshal ParseTime=time.Parse@1 time.ParseDuration ParseURL=net/url.Parse net/url.ParseQuery net/url.PathUnescape net/url.QueryUnescape CompileRegexp=regexp.Compile
+
+import (
+	"encoding/json"
+
+	"github.com/kdungs/go-result/result"
+)
+
+// Unmarshal decodes the JSON `data` into a `T`. It is `json.Unmarshal` as a
+// result function.
+func Unmarshal[T any](data []byte) (T, error) {
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return *new(T), err
+	}
+	return v, nil
+}
+
+// UnmarshalR is like `Unmarshal` but returns a `result.R[T]`.
+func UnmarshalR[T any](data []byte) result.R[T] {
+	return result.Wrap(Unmarshal[T](data))
+}
@@ -0,0 +1,228 @@
+// Code generated by resultgen. DO NOT EDIT.
+
+package std
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/kdungs/go-result/result"
+	"github.com/kdungs/go-result/then"
+)
+
+// Atoi is strconv.Atoi as a result function.
+func Atoi(s string) (int, error) {
+	return strconv.Atoi(s)
+}
+
+// AtoiR is like strconv.Atoi but returns a result.R.
+func AtoiR(s string) result.R[int] {
+	return result.Wrap(strconv.Atoi(s))
+}
+
+// ParseBool is strconv.ParseBool as a result function.
+func ParseBool(str string) (bool, error) {
+	return strconv.ParseBool(str)
+}
+
+// ParseBoolR is like strconv.ParseBool but returns a result.R.
+func ParseBoolR(str string) result.R[bool] {
+	return result.Wrap(strconv.ParseBool(str))
+}
+
+// ParseInt is strconv.ParseInt as a result function.
+// The parameter s is the input; all others are fixed by the arguments.
+func ParseInt(base int, bitSize int) then.FN[string, int64] {
+	return func(s string) (int64, error) {
+		return strconv.ParseInt(s, base, bitSize)
+	}
+}
+
+// ParseIntR is like strconv.ParseInt but returns a result.R.
+func ParseIntR(s string, base int, bitSize int) result.R[int64] {
+	return result.Wrap(strconv.ParseInt(s, base, bitSize))
+}
+
+// ParseUint is strconv.ParseUint as a result function.
+// The parameter s is the input; all others are fixed by the arguments.
+func ParseUint(base int, bitSize int) then.FN[string, uint64] {
+	return func(s string) (uint64, error) {
+		return strconv.ParseUint(s, base, bitSize)
+	}
+}
+
+// ParseUintR is like strconv.ParseUint but returns a result.R.
+func ParseUintR(s string, base int, bitSize int) result.R[uint64] {
+	return result.Wrap(strconv.ParseUint(s, base, bitSize))
+}
+
+// ParseFloat is strconv.ParseFloat as a result function.
+// The parameter s is the input; all others are fixed by the arguments.
+func ParseFloat(bitSize int) then.FN[string, float64] {
+	return func(s string) (float64, error) {
+		return strconv.ParseFloat(s, bitSize)
+	}
+}
+
+// ParseFloatR is like strconv.ParseFloat but returns a result.R.
+func ParseFloatR(s string, bitSize int) result.R[float64] {
+	return result.Wrap(strconv.ParseFloat(s, bitSize))
+}
+
+// Unquote is strconv.Unquote as a result function.
+func Unquote(s string) (string, error) {
+	return strconv.Unquote(s)
+}
+
+// UnquoteR is like strconv.Unquote but returns a result.R.
+func UnquoteR(s string) result.R[string] {
+	return result.Wrap(strconv.Unquote(s))
+}
+
+// ReadFile is os.ReadFile as a result function.
+func ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+// ReadFileR is like os.ReadFile but returns a result.R.
+func ReadFileR(name string) result.R[[]byte] {
+	return result.Wrap(os.ReadFile(name))
+}
+
+// ReadDir is os.ReadDir as a result function.
+func ReadDir(name string) ([]os.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+// ReadDirR is like os.ReadDir but returns a result.R.
+func ReadDirR(name string) result.R[[]os.DirEntry] {
+	return result.Wrap(os.ReadDir(name))
+}
+
+// Open is os.Open as a result function.
+func Open(name string) (*os.File, error) {
+	return os.Open(name)
+}
+
+// OpenR is like os.Open but returns a result.R.
+func OpenR(name string) result.R[*os.File] {
+	return result.Wrap(os.Open(name))
+}
+
+// Create is os.Create as a result function.
+func Create(name string) (*os.File, error) {
+	return os.Create(name)
+}
+
+// CreateR is like os.Create but returns a result.R.
+func CreateR(name string) result.R[*os.File] {
+	return result.Wrap(os.Create(name))
+}
+
+// Stat is os.Stat as a result function.
+func Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// StatR is like os.Stat but returns a result.R.
+func StatR(name string) result.R[os.FileInfo] {
+	return result.Wrap(os.Stat(name))
+}
+
+// ReadAll is io.ReadAll as a result function.
+func ReadAll(r io.Reader) ([]byte, error) {
+	return io.ReadAll(r)
+}
+
+// ReadAllR is like io.ReadAll but returns a result.R.
+func ReadAllR(r io.Reader) result.R[[]byte] {
+	return result.Wrap(io.ReadAll(r))
+}
+
+// Marshal is json.Marshal as a result function.
+func Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// MarshalR is like json.Marshal but returns a result.R.
+func MarshalR(v any) result.R[[]byte] {
+	return result.Wrap(json.Marshal(v))
+}
+
+// ParseTime is time.Parse as a result function.
+// The parameter value is the input; all others are fixed by the arguments.
+func ParseTime(layout string) then.FN[string, time.Time] {
+	return func(value string) (time.Time, error) {
+		return time.Parse(layout, value)
+	}
+}
+
+// ParseTimeR is like time.Parse but returns a result.R.
+func ParseTimeR(layout string, value string) result.R[time.Time] {
+	return result.Wrap(time.Parse(layout, value))
+}
+
+// ParseDuration is time.ParseDuration as a result function.
+func ParseDuration(s string) (time.Duration, error) {
+	return time.ParseDuration(s)
+}
+
+// ParseDurationR is like time.ParseDuration but returns a result.R.
+func ParseDurationR(s string) result.R[time.Duration] {
+	return result.Wrap(time.ParseDuration(s))
+}
+
+// ParseURL is url.Parse as a result function.
+func ParseURL(rawURL string) (*url.URL, error) {
+	return url.Parse(rawURL)
+}
+
+// ParseURLR is like url.Parse but returns a result.R.
+func ParseURLR(rawURL string) result.R[*url.URL] {
+	return result.Wrap(url.Parse(rawURL))
+}
+
+// ParseQuery is url.ParseQuery as a result function.
+func ParseQuery(query string) (url.Values, error) {
+	return url.ParseQuery(query)
+}
+
+// ParseQueryR is like url.ParseQuery but returns a result.R.
+func ParseQueryR(query string) result.R[url.Values] {
+	return result.Wrap(url.ParseQuery(query))
+}
+
+// PathUnescape is url.PathUnescape as a result function.
+func PathUnescape(s string) (string, error) {
+	return url.PathUnescape(s)
+}
+
+// PathUnescapeR is like url.PathUnescape but returns a result.R.
+func PathUnescapeR(s string) result.R[string] {
+	return result.Wrap(url.PathUnescape(s))
+}
+
+// QueryUnescape is url.QueryUnescape as a result function.
+func QueryUnescape(s string) (string, error) {
+	return url.QueryUnescape(s)
+}
+
+// QueryUnescapeR is like url.QueryUnescape but returns a result.R.
+func QueryUnescapeR(s string) result.R[string] {
+	return result.Wrap(url.QueryUnescape(s))
+}
+
+// CompileRegexp is regexp.Compile as a result function.
+func CompileRegexp(expr string) (*regexp.Regexp, error) {
+	return regexp.Compile(expr)
+}
+
+// CompileRegexpR is like regexp.Compile but returns a result.R.
+func CompileRegexpR(expr string) result.R[*regexp.Regexp] {
+	return result.Wrap(regexp.Compile(expr))
+}
@@ -0,0 +1,45 @@
+package std_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kdungs/go-result/result"
+	"github.com/kdungs/go-result/result/std"
+	"github.com/kdungs/go-result/then"
+)
+
+func TestPipeline(t *testing.T) {
+	double := then.Map(std.Atoi, func(x int) int { return 2 * x })
+	if v, err := double("21"); err != nil || v != 42 {
+		t.Fatalf("got (%d, %v), want (42, nil)", v, err)
+	}
+	var nerr *strconv.NumError
+	if _, err := double("x"); !errors.As(err, &nerr) {
+		t.Fatalf("got %v, want *strconv.NumError", err)
+	}
+}
+
+func TestCurried(t *testing.T) {
+	parse := std.ParseTime(time.DateOnly)
+	v, err := parse("2023-01-02")
+	if err != nil || !v.Equal(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("got (%v, %v), want (2023-01-02, nil)", v, err)
+	}
+	if v, err := std.ParseIntR("ff", 16, 64).Unwrap(); err != nil || v != 255 {
+		t.Fatalf("got (%d, %v), want (255, nil)", v, err)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	type point struct{ X, Y int }
+	p := result.MapE(std.MarshalR(point{1, 2}), std.Unmarshal[point])
+	if v, err := p.Unwrap(); err != nil || v != (point{1, 2}) {
+		t.Fatalf("got (%v, %v), want ({1 2}, nil)", v, err)
+	}
+	if _, err := std.UnmarshalR[point]([]byte("{")).Unwrap(); err == nil {
+		t.Fatal("got nil, want error")
+	}
+}
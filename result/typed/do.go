@@ -0,0 +1,52 @@
+package typed
+
+// Do applies `f` to the value contained in `r` if present or otherwise returns
+// the contained error.
+func Do[T any, E error](r R[T, E], f func(T)) error {
+	if r.failed {
+		return r.err
+	}
+	f(r.v)
+	return nil
+}
+
+// DoE applies `f` to the value contained in `r` if present and returns its
+// result unless that is nil. Otherwise returns the error contained in `r`.
+func DoE[T any, E error](r R[T, E], f func(T) E) error {
+	if r.failed {
+		return r.err
+	}
+	if err := f(r.v); !isNil(err) {
+		return err
+	}
+	return nil
+}
+
+// DoZip applies `f` to the values contained in `ra` and `rb` if both are
+// present. Otherwise returns the first error encountered (`ra` then `rb`).
+func DoZip[A, B any, E error](ra R[A, E], rb R[B, E], f func(A, B)) error {
+	if ra.failed {
+		return ra.err
+	}
+	if rb.failed {
+		return rb.err
+	}
+	f(ra.v, rb.v)
+	return nil
+}
+
+// DoZipE applies `f` to the values contained in `ra` and `rb` if both are
+// present and returns its result unless that is nil. Otherwise returns the
+// first error encountered (`ra` then `rb`).
+func DoZipE[A, B any, E error](ra R[A, E], rb R[B, E], f func(A, B) E) error {
+	if ra.failed {
+		return ra.err
+	}
+	if rb.failed {
+		return rb.err
+	}
+	if err := f(ra.v, rb.v); !isNil(err) {
+		return err
+	}
+	return nil
+}
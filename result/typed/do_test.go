@@ -0,0 +1,200 @@
+package typed_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/kdungs/go-result/result/typed"
+)
+
+func TestDo(t *testing.T) {
+	errV := &ParseError{Input: "v"}
+	cases := []struct {
+		name        string
+		r           typed.R[int, *ParseError]
+		expectedErr error
+		expected    int
+	}{
+		{
+			name:        "value",
+			r:           typed.Of[int, *ParseError](3),
+			expectedErr: nil,
+			expected:    3,
+		},
+		{
+			name:        "error",
+			r:           typed.OfErr[int](errV),
+			expectedErr: errV,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var got int
+			err := typed.Do(tc.r, func(i int) { got = i })
+			if err != tc.expectedErr {
+				t.Fatalf("got %v, want %v", err, tc.expectedErr)
+			}
+			if got != tc.expected {
+				t.Fatalf("got %d, want %d", got, tc.expected)
+			}
+		})
+	}
+}
+
+// check fails for numbers greater than 1. It returns a nil *ParseError
+// otherwise, which must not turn into a non-nil error.
+func check(x int) *ParseError {
+	if x > 1 {
+		return &ParseError{Input: strconv.Itoa(x)}
+	}
+	return nil
+}
+
+func TestDoE(t *testing.T) {
+	errV := &ParseError{Input: "v"}
+	cases := []struct {
+		name        string
+		r           typed.R[int, *ParseError]
+		expectedErr error
+	}{
+		{
+			name:        "error",
+			r:           typed.OfErr[int](errV),
+			expectedErr: errV,
+		},
+		{
+			name:        "function errors",
+			r:           typed.Of[int, *ParseError](2),
+			expectedErr: &ParseError{Input: "2"},
+		},
+		{
+			name:        "function succeeds",
+			r:           typed.Of[int, *ParseError](1),
+			expectedErr: nil,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := typed.DoE(tc.r, check)
+			if tc.expectedErr == nil {
+				if err != nil {
+					t.Fatalf("got %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectedErr.Error() {
+				t.Fatalf("got %v, want %v", err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestDoZip(t *testing.T) {
+	errA := &ParseError{Input: "a"}
+	errB := &ParseError{Input: "b"}
+	cases := []struct {
+		name        string
+		a           typed.R[int, *ParseError]
+		b           typed.R[int, *ParseError]
+		expectedErr error
+		expected    int
+	}{
+		{
+			name:        "both error",
+			a:           typed.OfErr[int](errA),
+			b:           typed.OfErr[int](errB),
+			expectedErr: errA,
+		},
+		{
+			name:        "a error",
+			a:           typed.OfErr[int](errA),
+			b:           typed.Of[int, *ParseError](2),
+			expectedErr: errA,
+		},
+		{
+			name:        "b error",
+			a:           typed.Of[int, *ParseError](1),
+			b:           typed.OfErr[int](errB),
+			expectedErr: errB,
+		},
+		{
+			name:        "both value",
+			a:           typed.Of[int, *ParseError](1),
+			b:           typed.Of[int, *ParseError](2),
+			expectedErr: nil,
+			expected:    3,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var sum int
+			err := typed.DoZip(tc.a, tc.b, func(x, y int) { sum = x + y })
+			if err != tc.expectedErr {
+				t.Fatalf("got %v, want %v", err, tc.expectedErr)
+			}
+			if sum != tc.expected {
+				t.Fatalf("got %d, want %d", sum, tc.expected)
+			}
+		})
+	}
+}
+
+func TestDoZipE(t *testing.T) {
+	errA := &ParseError{Input: "a"}
+	errB := &ParseError{Input: "b"}
+	cases := []struct {
+		name        string
+		a           typed.R[int, *ParseError]
+		b           typed.R[int, *ParseError]
+		expectedErr error
+	}{
+		{
+			name:        "both error",
+			a:           typed.OfErr[int](errA),
+			b:           typed.OfErr[int](errB),
+			expectedErr: errA,
+		},
+		{
+			name:        "a error",
+			a:           typed.OfErr[int](errA),
+			b:           typed.Of[int, *ParseError](0),
+			expectedErr: errA,
+		},
+		{
+			name:        "b error",
+			a:           typed.Of[int, *ParseError](0),
+			b:           typed.OfErr[int](errB),
+			expectedErr: errB,
+		},
+		{
+			name:        "function errors",
+			a:           typed.Of[int, *ParseError](1),
+			b:           typed.Of[int, *ParseError](1),
+			expectedErr: &ParseError{Input: "2"},
+		},
+		{
+			name:        "function succeeds",
+			a:           typed.Of[int, *ParseError](1),
+			b:           typed.Of[int, *ParseError](0),
+			expectedErr: nil,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := typed.DoZipE(tc.a, tc.b, func(x, y int) *ParseError { return check(x + y) })
+			if tc.expectedErr == nil {
+				if err != nil {
+					t.Fatalf("got %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectedErr.Error() {
+				t.Fatalf("got %v, want %v", err, tc.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package typed
+
+// Map applies an error-free function to a result of type `R[A, E]`. In case
+// the result is holding an error, the returned result will be holding the
+// same error.
+func Map[A, B any, E error](r R[A, E], f func(A) B) R[B, E] {
+	if r.failed {
+		return OfErr[B](r.err)
+	}
+	return Of[B, E](f(r.v))
+}
+
+// MapR applies a function whose return type is also a result to a result of
+// type `R[A, E]`. In case the result is holding an error, the returned result
+// will be holding the same error.
+func MapR[A, B any, E error](r R[A, E], f func(A) R[B, E]) R[B, E] {
+	if r.failed {
+		return OfErr[B](r.err)
+	}
+	return f(r.v)
+}
+
+// MapE does the same as `MapR` but works for regular Go functions that return
+// a value and an error of type E.
+func MapE[A, B any, E error](r R[A, E], f func(A) (B, E)) R[B, E] {
+	if r.failed {
+		return OfErr[B](r.err)
+	}
+	return Wrap(f(r.v))
+}
+
+// MapErr applies `f` to the error held by `r`, if any, changing the error
+// type from E to F. Values are passed through.
+func MapErr[T any, E, F error](r R[T, E], f func(E) F) R[T, F] {
+	if r.failed {
+		return OfErr[T](f(r.err))
+	}
+	return Of[T, F](r.v)
+}
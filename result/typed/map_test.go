@@ -0,0 +1,189 @@
+package typed_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kdungs/go-result/result/typed"
+)
+
+func TestMap(t *testing.T) {
+	errV := &ParseError{Input: "v"}
+	cases := []struct {
+		name        string
+		v           typed.R[int, *ParseError]
+		expectedErr error
+		expectedVal string
+	}{
+		{
+			name:        "error",
+			v:           typed.OfErr[int](errV),
+			expectedErr: errV,
+		},
+		{
+			name:        "value",
+			v:           typed.Of[int, *ParseError](42),
+			expectedErr: nil,
+			expectedVal: "42",
+		},
+	}
+	f := func(x int) string { return fmt.Sprint(x) }
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := typed.Map(tc.v, f).Unwrap()
+			if err != tc.expectedErr {
+				t.Fatalf("want %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil && v != tc.expectedVal {
+				t.Fatalf("want %q, got %q", tc.expectedVal, v)
+			}
+		})
+	}
+}
+
+func TestMapR(t *testing.T) {
+	errF := &ParseError{Input: "f"}
+	errV := &ParseError{Input: "v"}
+	returningError := func(int) typed.R[string, *ParseError] {
+		return typed.OfErr[string](errF)
+	}
+	returningValue := func(x int) typed.R[string, *ParseError] {
+		return typed.Of[string, *ParseError](fmt.Sprint(x))
+	}
+	cases := []struct {
+		name        string
+		f           func(int) typed.R[string, *ParseError]
+		v           typed.R[int, *ParseError]
+		expectedErr error
+		expectedVal string
+	}{
+		{
+			name:        "both are error",
+			f:           returningError,
+			v:           typed.OfErr[int](errV),
+			expectedErr: errV,
+		},
+		{
+			name:        "f is error",
+			f:           returningError,
+			v:           typed.Of[int, *ParseError](42),
+			expectedErr: errF,
+		},
+		{
+			name:        "v is error",
+			f:           returningValue,
+			v:           typed.OfErr[int](errV),
+			expectedErr: errV,
+		},
+		{
+			name:        "both are value",
+			f:           returningValue,
+			v:           typed.Of[int, *ParseError](42),
+			expectedErr: nil,
+			expectedVal: "42",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := typed.MapR(tc.v, tc.f).Unwrap()
+			if err != tc.expectedErr {
+				t.Fatalf("want %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil && v != tc.expectedVal {
+				t.Fatalf("want %q, got %q", tc.expectedVal, v)
+			}
+		})
+	}
+}
+
+func TestMapE(t *testing.T) {
+	errF := &ParseError{Input: "f"}
+	errV := &ParseError{Input: "v"}
+	returningError := func(int) (string, *ParseError) {
+		return "", errF
+	}
+	returningValue := func(x int) (string, *ParseError) {
+		return fmt.Sprint(x), nil
+	}
+	cases := []struct {
+		name        string
+		f           func(int) (string, *ParseError)
+		v           typed.R[int, *ParseError]
+		expectedErr error
+		expectedVal string
+	}{
+		{
+			name:        "both are error",
+			f:           returningError,
+			v:           typed.OfErr[int](errV),
+			expectedErr: errV,
+		},
+		{
+			name:        "f is error",
+			f:           returningError,
+			v:           typed.Of[int, *ParseError](42),
+			expectedErr: errF,
+		},
+		{
+			name:        "v is error",
+			f:           returningValue,
+			v:           typed.OfErr[int](errV),
+			expectedErr: errV,
+		},
+		{
+			name:        "both are value",
+			f:           returningValue,
+			v:           typed.Of[int, *ParseError](42),
+			expectedErr: nil,
+			expectedVal: "42",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := typed.MapE(tc.v, tc.f).Unwrap()
+			if err != tc.expectedErr {
+				t.Fatalf("want %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil && v != tc.expectedVal {
+				t.Fatalf("want %q, got %q", tc.expectedVal, v)
+			}
+		})
+	}
+}
+
+func TestMapErr(t *testing.T) {
+	toHTTP := func(e *ParseError) HTTPError { return HTTPError{Code: 400 + len(e.Input)} }
+	cases := []struct {
+		name        string
+		v           typed.R[int, *ParseError]
+		expectedErr error
+		expectedVal int
+	}{
+		{
+			name:        "error",
+			v:           typed.OfErr[int](&ParseError{Input: "x"}),
+			expectedErr: HTTPError{Code: 401},
+		},
+		{
+			name:        "value",
+			v:           typed.Of[int, *ParseError](42),
+			expectedErr: nil,
+			expectedVal: 42,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := typed.MapErr(tc.v, toHTTP).Unwrap()
+			if err != tc.expectedErr {
+				t.Fatalf("want %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil && v != tc.expectedVal {
+				t.Fatalf("want %d, got %d", tc.expectedVal, v)
+			}
+		})
+	}
+}
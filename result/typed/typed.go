@@ -0,0 +1,109 @@
+// Package typed defines a result type `R[T, E]` whose error has the concrete
+// type E, so that function signatures can express which errors they return.
+//
+// Go cannot tell whether a value of an arbitrary type E denotes an error or
+// not. Wherever this package receives an E from a function call, a nil value
+// (for pointer, interface and other nillable types) means "no error". E is
+// therefore usually a pointer type such as `*ValidationError`.
+package typed
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/kdungs/go-result/result"
+)
+
+// R is a generic result that can either hold a value of type T or an error of
+// type E.
+type R[T any, E error] struct {
+	err    E
+	failed bool
+	v      T
+}
+
+// Unwrap returns both the value and the error component of `r` like
+// `result.R.Unwrap`. The error is nil if `r` holds a value.
+func (r R[T, E]) Unwrap() (T, error) {
+	if r.failed {
+		return r.v, r.err
+	}
+	return r.v, nil
+}
+
+// Err returns the error held by `r` and whether there is one.
+func (r R[T, E]) Err() (E, bool) {
+	return r.err, r.failed
+}
+
+// Or returns the value part of `r` or the provided default value `d` in case
+// `r` is actually holding an error.
+func (r R[T, E]) Or(d T) T {
+	if r.failed {
+		return d
+	}
+	return r.v
+}
+
+// Untyped converts `r` to a `result.R[T]`. Converting back with `From`
+// restores `r`.
+func (r R[T, E]) Untyped() result.R[T] {
+	if r.failed {
+		return result.OfErr[T](r.err)
+	}
+	return result.Of(r.v)
+}
+
+// Wrap takes a value and an error and turns them into an `R[T, E]`. It holds
+// the error unless that is nil.
+func Wrap[T any, E error](v T, err E) R[T, E] {
+	if isNil(err) {
+		return Of[T, E](v)
+	}
+	return OfErr[T](err)
+}
+
+// Of constructs an `R[T, E]` from a value of type `T`.
+// Since `E` cannot be inferred from the value, calling code is required to
+// provide it, e.g. `Of[int, *ParseError](42)`.
+func Of[T any, E error](v T) R[T, E] {
+	return R[T, E]{v: v}
+}
+
+// OfErr constructs an `R[T, E]` holding an error, even if that is nil.
+// Since `T` cannot be inferred from the error alone, calling code is required
+// to provide it, e.g. `OfErr[int](&ParseError{})`.
+func OfErr[T any, E error](err E) R[T, E] {
+	return R[T, E]{err: err, failed: true}
+}
+
+// From converts a `result.R[T]` to an `R[T, E]`. If `r` holds an error that
+// is not an E, it is returned as the second return value instead. Errors
+// that wrap an E are unwrapped using `errors.As`.
+func From[E error, T any](r result.R[T]) (R[T, E], error) {
+	v, err := r.Unwrap()
+	if err == nil {
+		return Of[T, E](v), nil
+	}
+	if e, ok := err.(E); ok {
+		return OfErr[T](e), nil
+	}
+	var e E
+	if errors.As(err, &e) {
+		return OfErr[T](e), nil
+	}
+	return R[T, E]{}, err
+}
+
+// isNil reports whether `e` is nil, which is only possible for nillable types.
+func isNil[E error](e E) bool {
+	v := reflect.ValueOf(any(e))
+	if !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+package typed_test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/kdungs/go-result/result"
+	"github.com/kdungs/go-result/result/typed"
+)
+
+type ParseError struct{ Input string }
+
+func (e *ParseError) Error() string { return fmt.Sprintf("cannot parse %q", e.Input) }
+
+type HTTPError struct{ Code int }
+
+func (e HTTPError) Error() string { return strconv.Itoa(e.Code) }
+
+func TestWrap(t *testing.T) {
+	errX := &ParseError{Input: "x"}
+	cases := []struct {
+		name        string
+		v           int
+		err         *ParseError
+		expectedErr error
+		expectedVal int
+	}{
+		{
+			name:        "error",
+			err:         errX,
+			expectedErr: errX,
+		},
+		{
+			name:        "nil error",
+			v:           42,
+			err:         nil,
+			expectedErr: nil,
+			expectedVal: 42,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := typed.Wrap(tc.v, tc.err).Unwrap()
+			if err != tc.expectedErr {
+				t.Fatalf("want %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil && v != tc.expectedVal {
+				t.Fatalf("want %d, got %d", tc.expectedVal, v)
+			}
+		})
+	}
+}
+
+func TestWrapValueError(t *testing.T) {
+	// Value types are never nil, so they always count as errors.
+	e, ok := typed.Wrap(1, HTTPError{}).Err()
+	if !ok {
+		t.Fatalf("want %v, got no error", HTTPError{})
+	}
+	if e != (HTTPError{}) {
+		t.Fatalf("want %v, got %v", HTTPError{}, e)
+	}
+}
+
+func TestErr(t *testing.T) {
+	errX := &ParseError{Input: "x"}
+	cases := []struct {
+		name        string
+		r           typed.R[int, *ParseError]
+		expectedErr *ParseError
+		expectedOk  bool
+	}{
+		{
+			name:        "error",
+			r:           typed.OfErr[int](errX),
+			expectedErr: errX,
+			expectedOk:  true,
+		},
+		{
+			name:       "value",
+			r:          typed.Of[int, *ParseError](42),
+			expectedOk: false,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			e, ok := tc.r.Err()
+			if ok != tc.expectedOk {
+				t.Fatalf("want %v, got %v", tc.expectedOk, ok)
+			}
+			if e != tc.expectedErr {
+				t.Fatalf("want %v, got %v", tc.expectedErr, e)
+			}
+		})
+	}
+}
+
+func TestOr(t *testing.T) {
+	cases := []struct {
+		name     string
+		r        typed.R[string, *ParseError]
+		d        string
+		expected string
+	}{
+		{
+			name:     "no value uses default",
+			r:        typed.OfErr[string](&ParseError{Input: "x"}),
+			d:        "default value",
+			expected: "default value",
+		},
+		{
+			name:     "uses value if present",
+			r:        typed.Of[string, *ParseError]("foo bar"),
+			d:        "bar baz",
+			expected: "foo bar",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			v := tc.r.Or(tc.d)
+			if v != tc.expected {
+				t.Fatalf("want %q, got %q", tc.expected, v)
+			}
+		})
+	}
+}
+
+func TestUntyped(t *testing.T) {
+	cases := []struct {
+		name string
+		r    typed.R[int, *ParseError]
+	}{
+		{
+			name: "error",
+			r:    typed.OfErr[int](&ParseError{Input: "x"}),
+		},
+		{
+			name: "value",
+			r:    typed.Of[int, *ParseError](42),
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := typed.From[*ParseError](tc.r.Untyped())
+			if err != nil {
+				t.Fatalf("want no error, got %v", err)
+			}
+			if r != tc.r {
+				t.Fatalf("want %v, got %v", tc.r, r)
+			}
+		})
+	}
+}
+
+func TestFrom(t *testing.T) {
+	errX := &ParseError{Input: "x"}
+	errOther := errors.New("other")
+	cases := []struct {
+		name        string
+		r           result.R[int]
+		expectedErr error
+		expected    typed.R[int, *ParseError]
+	}{
+		{
+			name:     "value",
+			r:        result.Of(42),
+			expected: typed.Of[int, *ParseError](42),
+		},
+		{
+			name:     "error",
+			r:        result.OfErr[int](errX),
+			expected: typed.OfErr[int](errX),
+		},
+		{
+			name:     "wrapped error",
+			r:        result.OfErr[int](fmt.Errorf("loading: %w", errX)),
+			expected: typed.OfErr[int](errX),
+		},
+		{
+			name:        "other error",
+			r:           result.OfErr[int](errOther),
+			expectedErr: errOther,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := typed.From[*ParseError](tc.r)
+			if err != tc.expectedErr {
+				t.Fatalf("want %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil && r != tc.expected {
+				t.Fatalf("want %v, got %v", tc.expected, r)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package typed
+
+// Zip combines two results into one by applying a binary function that returns
+// a value to both values in case they exist. Otherwise returns whichever error
+// is encountered first.
+func Zip[A, B, C any, E error](ra R[A, E], rb R[B, E], f func(A, B) C) R[C, E] {
+	if ra.failed {
+		return OfErr[C](ra.err)
+	}
+	if rb.failed {
+		return OfErr[C](rb.err)
+	}
+	return Of[C, E](f(ra.v, rb.v))
+}
+
+// ZipR combines two results into one by applying a binary function returning a
+// result to both values in case they both exist. Otherwise returns whichever
+// error is encountered first.
+func ZipR[A, B, C any, E error](ra R[A, E], rb R[B, E], f func(A, B) R[C, E]) R[C, E] {
+	if ra.failed {
+		return OfErr[C](ra.err)
+	}
+	if rb.failed {
+		return OfErr[C](rb.err)
+	}
+	return f(ra.v, rb.v)
+}
+
+// ZipE does the same as `ZipR` but works for regular Go functions that return
+// a value and an error of type E.
+func ZipE[A, B, C any, E error](ra R[A, E], rb R[B, E], f func(A, B) (C, E)) R[C, E] {
+	if ra.failed {
+		return OfErr[C](ra.err)
+	}
+	if rb.failed {
+		return OfErr[C](rb.err)
+	}
+	return Wrap(f(ra.v, rb.v))
+}
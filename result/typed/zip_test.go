@@ -0,0 +1,187 @@
+package typed_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kdungs/go-result/result/typed"
+)
+
+func TestZip(t *testing.T) {
+	errA := &ParseError{Input: "a"}
+	errB := &ParseError{Input: "b"}
+	cases := []struct {
+		name        string
+		a           typed.R[int, *ParseError]
+		b           typed.R[string, *ParseError]
+		expectedErr error
+		expectedVal string
+	}{
+		{
+			name:        "both are error",
+			a:           typed.OfErr[int](errA),
+			b:           typed.OfErr[string](errB),
+			expectedErr: errA,
+		},
+		{
+			name:        "a is error",
+			a:           typed.OfErr[int](errA),
+			b:           typed.Of[string, *ParseError]("foo"),
+			expectedErr: errA,
+		},
+		{
+			name:        "b is error",
+			a:           typed.Of[int, *ParseError](42),
+			b:           typed.OfErr[string](errB),
+			expectedErr: errB,
+		},
+		{
+			name:        "both are value",
+			a:           typed.Of[int, *ParseError](42),
+			b:           typed.Of[string, *ParseError]("foo"),
+			expectedErr: nil,
+			expectedVal: "foo42",
+		},
+	}
+	f := func(a int, b string) string {
+		return fmt.Sprintf("%s%d", b, a)
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := typed.Zip(tc.a, tc.b, f).Unwrap()
+			if err != tc.expectedErr {
+				t.Fatalf("want %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil && v != tc.expectedVal {
+				t.Fatalf("want %q, got %q", tc.expectedVal, v)
+			}
+		})
+	}
+}
+
+func TestZipR(t *testing.T) {
+	errA := &ParseError{Input: "a"}
+	errB := &ParseError{Input: "b"}
+	errF := &ParseError{Input: "f"}
+	f := func(a int, b string) typed.R[string, *ParseError] {
+		if fmt.Sprintf("%d", a) == b {
+			return typed.OfErr[string](errF)
+		}
+		return typed.Of[string, *ParseError](fmt.Sprintf("%s%d", b, a))
+	}
+	cases := []struct {
+		name        string
+		a           typed.R[int, *ParseError]
+		b           typed.R[string, *ParseError]
+		expectedErr error
+		expectedVal string
+	}{
+		{
+			name:        "both are error",
+			a:           typed.OfErr[int](errA),
+			b:           typed.OfErr[string](errB),
+			expectedErr: errA,
+		},
+		{
+			name:        "a is error",
+			a:           typed.OfErr[int](errA),
+			b:           typed.Of[string, *ParseError]("foo"),
+			expectedErr: errA,
+		},
+		{
+			name:        "b is error",
+			a:           typed.Of[int, *ParseError](42),
+			b:           typed.OfErr[string](errB),
+			expectedErr: errB,
+		},
+		{
+			name:        "f is error",
+			a:           typed.Of[int, *ParseError](42),
+			b:           typed.Of[string, *ParseError]("42"),
+			expectedErr: errF,
+		},
+		{
+			name:        "both are value",
+			a:           typed.Of[int, *ParseError](42),
+			b:           typed.Of[string, *ParseError]("foo"),
+			expectedErr: nil,
+			expectedVal: "foo42",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := typed.ZipR(tc.a, tc.b, f).Unwrap()
+			if err != tc.expectedErr {
+				t.Fatalf("want %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil && v != tc.expectedVal {
+				t.Fatalf("want %q, got %q", tc.expectedVal, v)
+			}
+		})
+	}
+}
+
+func TestZipE(t *testing.T) {
+	errA := &ParseError{Input: "a"}
+	errB := &ParseError{Input: "b"}
+	errF := &ParseError{Input: "f"}
+	f := func(a int, b string) (string, *ParseError) {
+		if fmt.Sprintf("%d", a) == b {
+			return "", errF
+		}
+		return fmt.Sprintf("%s%d", b, a), nil
+	}
+	cases := []struct {
+		name        string
+		a           typed.R[int, *ParseError]
+		b           typed.R[string, *ParseError]
+		expectedErr error
+		expectedVal string
+	}{
+		{
+			name:        "both are error",
+			a:           typed.OfErr[int](errA),
+			b:           typed.OfErr[string](errB),
+			expectedErr: errA,
+		},
+		{
+			name:        "a is error",
+			a:           typed.OfErr[int](errA),
+			b:           typed.Of[string, *ParseError]("foo"),
+			expectedErr: errA,
+		},
+		{
+			name:        "b is error",
+			a:           typed.Of[int, *ParseError](42),
+			b:           typed.OfErr[string](errB),
+			expectedErr: errB,
+		},
+		{
+			name:        "f is error",
+			a:           typed.Of[int, *ParseError](42),
+			b:           typed.Of[string, *ParseError]("42"),
+			expectedErr: errF,
+		},
+		{
+			name:        "both are value",
+			a:           typed.Of[int, *ParseError](42),
+			b:           typed.Of[string, *ParseError]("foo"),
+			expectedErr: nil,
+			expectedVal: "foo42",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := typed.ZipE(tc.a, tc.b, f).Unwrap()
+			if err != tc.expectedErr {
+				t.Fatalf("want %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr == nil && v != tc.expectedVal {
+				t.Fatalf("want %q, got %q", tc.expectedVal, v)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package then
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned by a `Breaker` that rejects a call because it is open.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of a circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets all calls through and counts failures.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects all calls with `ErrOpen` until the cooldown has
+	// passed.
+	BreakerOpen
+	// BreakerHalfOpen lets a single trial call through to decide whether to
+	// close again or to re-open.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// BreakerConfig configures a `Breaker`.
+type BreakerConfig struct {
+	// Threshold is the number of failures that opens the breaker. Values below
+	// 1 are treated as 1.
+	Threshold int
+	// Window is the rolling window in which failures are counted. If it is
+	// zero, only consecutive failures count, i.e. every success resets the
+	// count.
+	Window time.Duration
+	// Cooldown is how long the breaker stays open before it lets a trial call
+	// through.
+	Cooldown time.Duration
+	// IsFailure decides whether an error counts as a failure. Errors that
+	// don't are returned to the caller but treated like a success by the
+	// breaker. If nil, every error counts.
+	IsFailure func(error) bool
+	// OnStateChange, if not nil, is called after every state transition.
+	OnStateChange func(from, to BreakerState)
+	// Clock is used to measure windows and cooldowns. If nil, `SystemClock`
+	// is used.
+	Clock Clock
+}
+
+// Breaker protects the dependency called by `f` with a circuit breaker. After
+// `cfg.Threshold` failures the breaker opens and calls fail fast with
+// `ErrOpen` instead of calling `f`. Once `cfg.Cooldown` has passed, a single
+// trial call decides whether to close the breaker again.
+func Breaker[A, B any](f FN[A, B], cfg BreakerConfig) FN[A, B] {
+	br := &breaker{cfg: cfg}
+	if br.cfg.Clock == nil {
+		br.cfg.Clock = SystemClock
+	}
+	if br.cfg.Threshold < 1 {
+		br.cfg.Threshold = 1
+	}
+	return func(a A) (B, error) {
+		tok, err := br.acquire()
+		if err != nil {
+			return *new(B), err
+		}
+		// A panicking call counts as a failure.
+		failure := true
+		defer func() { br.record(tok, failure) }()
+		b, err := f(a)
+		failure = err != nil && (br.cfg.IsFailure == nil || br.cfg.IsFailure(err))
+		return b, err
+	}
+}
+
+type transition struct{ from, to BreakerState }
+
+type breaker struct {
+	cfg BreakerConfig
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures []time.Time
+	openedAt time.Time
+	trial    bool
+	// gen is incremented on every transition so that calls that finish after
+	// the breaker changed state don't affect the new state.
+	gen uint64
+}
+
+// token identifies the state a call was let through in.
+type token struct {
+	gen uint64
+}
+
+func (br *breaker) acquire() (token, error) {
+	br.mu.Lock()
+	var ts []transition
+	defer func() {
+		br.mu.Unlock()
+		br.notify(ts)
+	}()
+	if br.state == BreakerOpen {
+		if br.cfg.Clock.Now().Sub(br.openedAt) < br.cfg.Cooldown {
+			return token{}, ErrOpen
+		}
+		ts = append(ts, br.transition(BreakerHalfOpen))
+	}
+	if br.state == BreakerHalfOpen {
+		if br.trial {
+			return token{}, ErrOpen
+		}
+		br.trial = true
+	}
+	return token{br.gen}, nil
+}
+
+func (br *breaker) record(tok token, failure bool) {
+	br.mu.Lock()
+	var ts []transition
+	defer func() {
+		br.mu.Unlock()
+		br.notify(ts)
+	}()
+	if tok.gen != br.gen {
+		return
+	}
+	now := br.cfg.Clock.Now()
+	switch br.state {
+	case BreakerHalfOpen:
+		br.trial = false
+		if failure {
+			br.openedAt = now
+			ts = append(ts, br.transition(BreakerOpen))
+			return
+		}
+		br.failures = nil
+		ts = append(ts, br.transition(BreakerClosed))
+	case BreakerClosed:
+		if !failure {
+			if br.cfg.Window == 0 {
+				br.failures = br.failures[:0]
+			}
+			return
+		}
+		br.failures = append(br.failures, now)
+		if br.cfg.Window > 0 {
+			i := 0
+			for i < len(br.failures) && now.Sub(br.failures[i]) >= br.cfg.Window {
+				i++
+			}
+			br.failures = br.failures[i:]
+		}
+		if len(br.failures) >= br.cfg.Threshold {
+			br.failures = nil
+			br.openedAt = now
+			ts = append(ts, br.transition(BreakerOpen))
+		}
+	}
+}
+
+func (br *breaker) transition(to BreakerState) transition {
+	t := transition{br.state, to}
+	br.state = to
+	br.gen++
+	return t
+}
+
+func (br *breaker) notify(ts []transition) {
+	if br.cfg.OnStateChange == nil {
+		return
+	}
+	for _, t := range ts {
+		br.cfg.OnStateChange(t.from, t.to)
+	}
+}
@@ -0,0 +1,235 @@
+package then_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kdungs/go-result/then"
+	"github.com/kdungs/go-result/then/thentest"
+)
+
+func TestBreaker(t *testing.T) {
+	errF := errors.New("f")
+	errIgnored := errors.New("ignored")
+	type call struct {
+		advance     time.Duration
+		err         error
+		expectedErr error
+	}
+	cases := []struct {
+		name        string
+		cfg         then.BreakerConfig
+		calls       []call
+		transitions []string
+	}{
+		{
+			name: "opens after threshold",
+			cfg:  then.BreakerConfig{Threshold: 2, Cooldown: time.Minute},
+			calls: []call{
+				{err: errF, expectedErr: errF},
+				{err: errF, expectedErr: errF},
+				{err: nil, expectedErr: then.ErrOpen},
+				{advance: 59 * time.Second, err: nil, expectedErr: then.ErrOpen},
+			},
+			transitions: []string{"closed->open"},
+		},
+		{
+			name: "success resets consecutive failures",
+			cfg:  then.BreakerConfig{Threshold: 2},
+			calls: []call{
+				{err: errF, expectedErr: errF},
+				{err: nil, expectedErr: nil},
+				{err: errF, expectedErr: errF},
+				{err: nil, expectedErr: nil},
+			},
+		},
+		{
+			name: "failures outside window are forgotten",
+			cfg:  then.BreakerConfig{Threshold: 2, Window: time.Minute, Cooldown: time.Minute},
+			calls: []call{
+				{err: errF, expectedErr: errF},
+				{advance: time.Minute, err: errF, expectedErr: errF},
+				{err: nil, expectedErr: nil},
+				{err: errF, expectedErr: errF},
+				{err: nil, expectedErr: then.ErrOpen},
+			},
+			transitions: []string{"closed->open"},
+		},
+		{
+			name: "trial success closes",
+			cfg:  then.BreakerConfig{Threshold: 1, Cooldown: time.Minute},
+			calls: []call{
+				{err: errF, expectedErr: errF},
+				{advance: time.Minute, err: nil, expectedErr: nil},
+				{err: errF, expectedErr: errF},
+			},
+			transitions: []string{"closed->open", "open->half-open", "half-open->closed", "closed->open"},
+		},
+		{
+			name: "trial failure re-opens",
+			cfg:  then.BreakerConfig{Threshold: 1, Cooldown: time.Minute},
+			calls: []call{
+				{err: errF, expectedErr: errF},
+				{advance: time.Minute, err: errF, expectedErr: errF},
+				{advance: 30 * time.Second, err: nil, expectedErr: then.ErrOpen},
+			},
+			transitions: []string{"closed->open", "open->half-open", "half-open->open"},
+		},
+		{
+			name: "classifier ignores errors",
+			cfg: then.BreakerConfig{
+				Threshold: 1,
+				Cooldown:  time.Minute,
+				IsFailure: func(err error) bool { return !errors.Is(err, errIgnored) },
+			},
+			calls: []call{
+				{err: errIgnored, expectedErr: errIgnored},
+				{err: errIgnored, expectedErr: errIgnored},
+				{err: errF, expectedErr: errF},
+				{err: nil, expectedErr: then.ErrOpen},
+			},
+			transitions: []string{"closed->open"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			clock := thentest.NewClock()
+			var transitions []string
+			cfg := tc.cfg
+			cfg.Clock = clock
+			cfg.OnStateChange = func(from, to then.BreakerState) {
+				transitions = append(transitions, fmt.Sprintf("%v->%v", from, to))
+			}
+			var next error
+			f := then.Breaker(func(x int) (int, error) { return x, next }, cfg)
+			for i, c := range tc.calls {
+				clock.Advance(c.advance)
+				next = c.err
+				if _, err := f(i); !errors.Is(err, c.expectedErr) || (c.expectedErr == nil && err != nil) {
+					t.Fatalf("call %d: got %v, want %v", i, err, c.expectedErr)
+				}
+			}
+			if !reflect.DeepEqual(transitions, tc.transitions) {
+				t.Fatalf("got %v, want %v", transitions, tc.transitions)
+			}
+		})
+	}
+}
+
+func TestBreakerHalfOpenSingleTrial(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	fail := true
+	f := then.Breaker(func(x int) (int, error) {
+		if fail {
+			return 0, errors.New("f")
+		}
+		started <- struct{}{}
+		<-release
+		return x, nil
+	}, then.BreakerConfig{})
+	f(0)
+	fail = false
+	done := make(chan error)
+	go func() {
+		_, err := f(1)
+		done <- err
+	}()
+	<-started
+	if _, err := f(2); err != then.ErrOpen {
+		t.Fatalf("got %v, want %v", err, then.ErrOpen)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if _, err := f(3); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+}
+
+func TestBreakerIgnoresStaleResults(t *testing.T) {
+	clock := thentest.NewClock()
+	var transitions []string
+	errF := errors.New("f")
+	release := map[int]chan error{0: make(chan error), 2: make(chan error)}
+	started := make(chan int, 2)
+	f := then.Breaker(func(x int) (int, error) {
+		if ch, ok := release[x]; ok {
+			started <- x
+			return x, <-ch
+		}
+		return x, errF
+	}, then.BreakerConfig{
+		Threshold: 1,
+		Cooldown:  time.Minute,
+		Clock:     clock,
+		OnStateChange: func(from, to then.BreakerState) {
+			transitions = append(transitions, fmt.Sprintf("%v->%v", from, to))
+		},
+	})
+
+	done := make(chan error, 2)
+	call := func(x int) {
+		_, err := f(x)
+		done <- err
+	}
+	// Call 0 starts while the breaker is closed ...
+	go call(0)
+	<-started
+	f(1)
+	clock.Advance(time.Minute)
+	// ... and finishes while call 2 is the trial of the half-open breaker.
+	go call(2)
+	<-started
+	release[0] <- nil
+	if err := <-done; err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if _, err := f(3); err != then.ErrOpen {
+		t.Fatalf("got %v, want %v", err, then.ErrOpen)
+	}
+	release[2] <- errF
+	if err := <-done; err != errF {
+		t.Fatalf("got %v, want %v", err, errF)
+	}
+	want := []string{"closed->open", "open->half-open", "half-open->open"}
+	if !reflect.DeepEqual(transitions, want) {
+		t.Fatalf("got %v, want %v", transitions, want)
+	}
+}
+
+func TestBreakerTrialPanics(t *testing.T) {
+	clock := thentest.NewClock()
+	var next func() error
+	f := then.Breaker(func(x int) (int, error) {
+		return x, next()
+	}, then.BreakerConfig{Threshold: 1, Cooldown: time.Minute, Clock: clock})
+
+	next = func() error { return errors.New("f") }
+	f(0)
+	clock.Advance(time.Minute)
+	next = func() error { panic("boom") }
+	func() {
+		defer func() {
+			if p := recover(); p != "boom" {
+				t.Fatalf("got %v, want %q", p, "boom")
+			}
+		}()
+		f(1)
+	}()
+	next = func() error { return nil }
+	if _, err := f(2); err != then.ErrOpen {
+		t.Fatalf("got %v, want %v", err, then.ErrOpen)
+	}
+	clock.Advance(time.Minute)
+	for i := 3; i < 5; i++ {
+		if _, err := f(i); err != nil {
+			t.Fatalf("call %d: got %v, want nil", i, err)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package then
+
+import (
+	"context"
+	"time"
+)
+
+// Clock abstracts the passage of time for stages such as `Timeout` so that they
+// can be tested deterministically.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel that receives the current time once `d` has
+	// elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is the `Clock` backed by package time.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+type clockKey struct{}
+
+// WithClock returns a copy of `ctx` that carries `c`. Time-dependent stages
+// consult the clock in their context, falling back to `SystemClock`.
+func WithClock(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, c)
+}
+
+// ClockFrom returns the `Clock` carried by `ctx` or `SystemClock` if there is
+// none.
+func ClockFrom(ctx context.Context) Clock {
+	if c, ok := ctx.Value(clockKey{}).(Clock); ok {
+		return c
+	}
+	return SystemClock
+}
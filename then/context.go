@@ -0,0 +1,35 @@
+package then
+
+import "context"
+
+// FC is a context-aware result function. It is the building block for stages
+// that need to observe cancellation, deadlines or a `Clock` (see `Timeout`).
+type FC[A, B any] func(context.Context, A) (B, error)
+
+// Ctx elevates a result function to a context-aware one by ignoring the
+// context. Note that the resulting function cannot be interrupted.
+func Ctx[A, B any](f FN[A, B]) FC[A, B] {
+	return func(_ context.Context, a A) (B, error) {
+		return f(a)
+	}
+}
+
+// Bind turns a context-aware result function into a regular one by fixing the
+// context to `ctx`, so it can be used with `Chain`, `Map`, `Zip` etc.
+func Bind[A, B any](ctx context.Context, f FC[A, B]) FN[A, B] {
+	return func(a A) (B, error) {
+		return f(ctx, a)
+	}
+}
+
+// ChainC composes two context-aware result functions. Both are called with the
+// same context.
+func ChainC[A, B, C any](f FC[A, B], g FC[B, C]) FC[A, C] {
+	return func(ctx context.Context, a A) (C, error) {
+		b, err := f(ctx, a)
+		if err != nil {
+			return *new(C), err
+		}
+		return g(ctx, b)
+	}
+}
@@ -0,0 +1,126 @@
+// Package env implements composition of result functions that depend on a
+// shared environment, such as a database handle, a logger or configuration.
+//
+// Instead of capturing dependencies in closures, stages take the environment
+// as their first argument. Pipelines are composed just like with package then
+// and only bound to a concrete environment at the end via `Provide`, which
+// makes it easy to run the same pipeline against fakes in tests.
+package env
+
+import "github.com/kdungs/go-result/then"
+
+type (
+	// FN is a result function that depends on an environment of type Env.
+	FN[Env, A, B any] func(Env, A) (B, error)
+
+	// FE is a consuming function that depends on an environment of type Env
+	// and returns an error.
+	FE[Env, A any] func(Env, A) error
+)
+
+// Lift turns a result function that doesn't need the environment into an
+// `FN`. Since `Env` cannot be inferred, calling code is required to provide
+// it, e.g. `Lift[Deps](strconv.Atoi)`.
+func Lift[Env, A, B any](f then.FN[A, B]) FN[Env, A, B] {
+	return func(_ Env, a A) (B, error) {
+		return f(a)
+	}
+}
+
+// LiftE is `Lift` for consuming functions.
+func LiftE[Env, A any](f then.FE[A]) FE[Env, A] {
+	return func(_ Env, a A) error {
+		return f(a)
+	}
+}
+
+// Local adapts `f`, which only needs a part of the environment, to the
+// environment Env by selecting that part with `sel`.
+func Local[Env, Sub, A, B any](f FN[Sub, A, B], sel func(Env) Sub) FN[Env, A, B] {
+	return func(e Env, a A) (B, error) {
+		return f(sel(e), a)
+	}
+}
+
+// Chain composes two result functions, passing both the same environment.
+func Chain[Env, A, B, C any](f FN[Env, A, B], g FN[Env, B, C]) FN[Env, A, C] {
+	return func(e Env, a A) (C, error) {
+		b, err := f(e, a)
+		if err != nil {
+			return *new(C), err
+		}
+		return g(e, b)
+	}
+}
+
+// Map combines a result function with an error-free one.
+func Map[Env, A, B, C any](f FN[Env, A, B], g then.F[B, C]) FN[Env, A, C] {
+	return func(e Env, a A) (C, error) {
+		b, err := f(e, a)
+		if err != nil {
+			return *new(C), err
+		}
+		return g(b), nil
+	}
+}
+
+// Do combines a result function with a consuming function that returns an
+// error.
+func Do[Env, A, B any](f FN[Env, A, B], g FE[Env, B]) FE[Env, A] {
+	return func(e Env, a A) error {
+		b, err := f(e, a)
+		if err != nil {
+			return err
+		}
+		return g(e, b)
+	}
+}
+
+// Zip combines two result functions by applying a binary result function to
+// their (non-error) results. If one of the results is an error, that error is
+// returned instead.
+func Zip[Env, A, B, C, D, E any](f FN[Env, A, B], g FN[Env, C, D], with func(B, D) (E, error)) func(Env, A, C) (E, error) {
+	return func(e Env, a A, c C) (E, error) {
+		b, err := f(e, a)
+		if err != nil {
+			return *new(E), err
+		}
+		d, err := g(e, c)
+		if err != nil {
+			return *new(E), err
+		}
+		return with(b, d)
+	}
+}
+
+// Merge combines two result functions by applying a binary consuming function
+// that returns an error to their (non-error) results. If one of the results
+// is an error, that error is returned instead.
+func Merge[Env, A, B, C, D any](f FN[Env, A, B], g FN[Env, C, D], with func(B, D) error) func(Env, A, C) error {
+	return func(e Env, a A, c C) error {
+		b, err := f(e, a)
+		if err != nil {
+			return err
+		}
+		d, err := g(e, c)
+		if err != nil {
+			return err
+		}
+		return with(b, d)
+	}
+}
+
+// Provide binds `f` to the environment `e`, turning it into a plain result
+// function.
+func Provide[Env, A, B any](f FN[Env, A, B], e Env) then.FN[A, B] {
+	return func(a A) (B, error) {
+		return f(e, a)
+	}
+}
+
+// ProvideE is `Provide` for consuming functions.
+func ProvideE[Env, A any](f FE[Env, A], e Env) then.FE[A] {
+	return func(a A) error {
+		return f(e, a)
+	}
+}
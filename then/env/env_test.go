@@ -0,0 +1,214 @@
+package env_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kdungs/go-result/then"
+	"github.com/kdungs/go-result/then/env"
+	"github.com/kdungs/go-result/then/thentest"
+)
+
+type Store interface {
+	Get(id int) (string, error)
+}
+
+type Deps struct {
+	Store Store
+	Clock then.Clock
+	Log   *strings.Builder
+}
+
+type mapStore map[int]string
+
+func (m mapStore) Get(id int) (string, error) {
+	s, ok := m[id]
+	if !ok {
+		return "", fmt.Errorf("no record %d", id)
+	}
+	return s, nil
+}
+
+type failingStore struct{ err error }
+
+func (f failingStore) Get(int) (string, error) { return "", f.err }
+
+func fetch(s Store, id int) (string, error) {
+	return s.Get(id)
+}
+
+func stamp(d Deps, s string) (string, error) {
+	return d.Clock.Now().Format(time.DateOnly) + " " + s, nil
+}
+
+func logLine(d Deps, s string) error {
+	_, err := fmt.Fprintln(d.Log, s)
+	return err
+}
+
+// pipeline parses an ID, fetches the record, and logs it with a timestamp.
+var pipeline = env.Do(
+	env.Chain(
+		env.Chain(
+			env.Lift[Deps](strconv.Atoi),
+			env.Local(fetch, func(d Deps) Store { return d.Store }),
+		),
+		env.Map(stamp, strings.ToUpper),
+	),
+	logLine,
+)
+
+func TestProvide(t *testing.T) {
+	clock := thentest.NewClock()
+	var log strings.Builder
+	deps := Deps{Store: mapStore{1: "alice"}, Clock: clock, Log: &log}
+	errDown := errors.New("down")
+
+	cases := []struct {
+		name        string
+		deps        Deps
+		in          string
+		expectedErr string
+	}{
+		{
+			name: "value",
+			deps: deps,
+			in:   "1",
+		},
+		{
+			name:        "missing record",
+			deps:        deps,
+			in:          "2",
+			expectedErr: "no record 2",
+		},
+		{
+			name:        "invalid ID",
+			deps:        deps,
+			in:          "x",
+			expectedErr: `strconv.Atoi: parsing "x": invalid syntax`,
+		},
+		{
+			// The same pipeline runs against a different environment.
+			name:        "failing store",
+			deps:        Deps{Store: failingStore{err: errDown}, Clock: clock, Log: &strings.Builder{}},
+			in:          "1",
+			expectedErr: errDown.Error(),
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := env.ProvideE(pipeline, tc.deps)(tc.in)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("got %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectedErr {
+				t.Fatalf("got %v, want %q", err, tc.expectedErr)
+			}
+		})
+	}
+	expected := clock.Now().Format(time.DateOnly) + " ALICE\n"
+	if log.String() != expected {
+		t.Fatalf("got %q, want %q", log.String(), expected)
+	}
+}
+
+func TestZip(t *testing.T) {
+	deps := Deps{Store: mapStore{1: "alice", 2: "bob"}}
+	get := env.Local(fetch, func(d Deps) Store { return d.Store })
+	zip := env.Zip(get, get, func(a, b string) (string, error) { return a + "&" + b, nil })
+	cases := []struct {
+		name        string
+		a           int
+		b           int
+		expectedErr bool
+		expectedVal string
+	}{
+		{
+			name:        "both are value",
+			a:           1,
+			b:           2,
+			expectedVal: "alice&bob",
+		},
+		{
+			name:        "b is error",
+			a:           1,
+			b:           3,
+			expectedErr: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := zip(deps, tc.a, tc.b)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("got %v, want error: %v", err, tc.expectedErr)
+			}
+			if err == nil && v != tc.expectedVal {
+				t.Fatalf("got %q, want %q", v, tc.expectedVal)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	deps := Deps{Store: mapStore{1: "alice", 2: "bob"}}
+	get := env.Local(fetch, func(d Deps) Store { return d.Store })
+	cases := []struct {
+		name        string
+		a           int
+		b           int
+		expectedErr bool
+		expected    []string
+	}{
+		{
+			name:     "both are value",
+			a:        2,
+			b:        7,
+			expected: []string{"bob7"},
+		},
+		{
+			name:        "a is error",
+			a:           3,
+			b:           7,
+			expectedErr: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			merge := env.Merge(get, env.Lift[Deps](then.Lift(strconv.Itoa)), func(a, b string) error {
+				got = append(got, a+b)
+				return nil
+			})
+			err := merge(deps, tc.a, tc.b)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("got %v, want error: %v", err, tc.expectedErr)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Fatalf("got %q, want %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestLiftAndProvide(t *testing.T) {
+	deps := Deps{Store: mapStore{2: "bob"}}
+	fe := env.ProvideE(env.LiftE[Deps](func(s string) error { return nil }), deps)
+	if err := fe("x"); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	get := env.Local(fetch, func(d Deps) Store { return d.Store })
+	if s, err := env.Provide(get, deps)(2); err != nil || s != "bob" {
+		t.Fatalf("got (%q, %v), want (%q, nil)", s, err, "bob")
+	}
+}
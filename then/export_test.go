@@ -0,0 +1,17 @@
+package then
+
+// SetSingleFlightJoinHook sets the hook called when a caller joins a
+// `SingleFlight` call in flight and returns a function restoring the old one.
+func SetSingleFlightJoinHook(f func()) func() {
+	old := testHookSingleFlightJoin
+	testHookSingleFlightJoin = f
+	return func() { testHookSingleFlightJoin = old }
+}
+
+// SetBulkheadQueuedHook sets the hook called when a call starts waiting in
+// the queue of a bulkhead and returns a function restoring the old one.
+func SetBulkheadQueuedHook(f func()) func() {
+	old := testHookBulkheadQueued
+	testHookBulkheadQueued = f
+	return func() { testHookBulkheadQueued = old }
+}
@@ -1,3 +1,3 @@
 module github.com/kdungs/go-result/then
 
-go 1.20
+go 1.23
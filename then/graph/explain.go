@@ -0,0 +1,155 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Signature returns the type signature of `n`, e.g. "(string, int) -> bool".
+// Consumers return "error".
+func (n *Node) Signature() string {
+	in := strings.Join(n.In, ", ")
+	if len(n.In) != 1 {
+		in = "(" + in + ")"
+	}
+	out := n.Out
+	if out == "" {
+		out = "error"
+	}
+	return in + " -> " + out
+}
+
+// Explain renders `n` as an indented tree, one node per line.
+func (n *Node) Explain() string {
+	var b strings.Builder
+	n.explain(&b, 0)
+	return b.String()
+}
+
+func (n *Node) explain(b *strings.Builder, depth int) {
+	b.WriteString(strings.Repeat("  ", depth))
+	if n.Kind == KindStage {
+		fmt.Fprintf(b, "%s %q: %s\n", n.Kind, n.Name, n.Signature())
+		return
+	}
+	fmt.Fprintf(b, "%s: %s\n", n.Kind, n.Signature())
+	for _, c := range n.Children {
+		c.explain(b, depth+1)
+	}
+}
+
+// flow is the data flow graph of a pipeline: stages connected by edges that
+// are labelled with the name of the type flowing along them.
+type flow struct {
+	stages []*Node
+	edges  []edge
+}
+
+type edge struct {
+	from, to int // indices into stages; -1 denotes the input or output
+	label    string
+}
+
+func newFlow(n *Node) *flow {
+	f := &flow{}
+	entries, exits := f.add(n)
+	for _, e := range entries {
+		f.edges = append(f.edges, edge{-1, e, f.stages[e].In[0]})
+	}
+	for _, e := range exits {
+		if out := f.stages[e].Out; out != "" {
+			f.edges = append(f.edges, edge{e, -1, out})
+		}
+	}
+	return f
+}
+
+// add adds `n` to the graph and returns the indices of the stages through
+// which data enters and leaves it.
+func (f *flow) add(n *Node) (entries, exits []int) {
+	switch n.Kind {
+	case KindStage:
+		f.stages = append(f.stages, n)
+		i := len(f.stages) - 1
+		return []int{i}, []int{i}
+	case KindZip, KindMerge:
+		pIn, pOut := f.add(n.Children[0])
+		qIn, qOut := f.add(n.Children[1])
+		_, join := f.add(n.Children[2])
+		f.connect(pOut, join)
+		f.connect(qOut, join)
+		return append(pIn, qIn...), join
+	default:
+		entries, exits = f.add(n.Children[0])
+		for _, c := range n.Children[1:] {
+			in, out := f.add(c)
+			f.connect(exits, in)
+			exits = out
+		}
+		return entries, exits
+	}
+}
+
+func (f *flow) connect(from, to []int) {
+	for _, a := range from {
+		for _, b := range to {
+			f.edges = append(f.edges, edge{a, b, f.stages[a].Out})
+		}
+	}
+}
+
+// DOT renders the data flow of `n` in the Graphviz DOT language.
+func (n *Node) DOT() string {
+	f := newFlow(n)
+	id := func(i int, end string) string {
+		if i < 0 {
+			return end
+		}
+		return fmt.Sprintf("n%d", i)
+	}
+	var b strings.Builder
+	b.WriteString("digraph pipeline {\n\trankdir=LR;\n\tnode [shape=box];\n")
+	b.WriteString("\tinput [shape=circle, label=\"in\"];\n")
+	if n.Out != "" {
+		b.WriteString("\toutput [shape=circle, label=\"out\"];\n")
+	}
+	for i, s := range f.stages {
+		fmt.Fprintf(&b, "\tn%d [label=\"%s\", tooltip=\"%s\"];\n", i, escapeDOT(s.Name), escapeDOT(s.Signature()))
+	}
+	for _, e := range f.edges {
+		fmt.Fprintf(&b, "\t%s -> %s [label=\"%s\"];\n", id(e.from, "input"), id(e.to, "output"), escapeDOT(e.label))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid renders the data flow of `n` as a Mermaid flowchart.
+func (n *Node) Mermaid() string {
+	f := newFlow(n)
+	id := func(i int, end string) string {
+		if i < 0 {
+			return end
+		}
+		return fmt.Sprintf("n%d", i)
+	}
+	var b strings.Builder
+	b.WriteString("flowchart LR\n\tinput((in))\n")
+	if n.Out != "" {
+		b.WriteString("\toutput((out))\n")
+	}
+	for i, s := range f.stages {
+		fmt.Fprintf(&b, "\tn%d[\"%s\"]\n", i, escapeMermaid(s.Name))
+	}
+	for _, e := range f.edges {
+		fmt.Fprintf(&b, "\t%s -->|\"%s\"| %s\n", id(e.from, "input"), escapeMermaid(e.label), id(e.to, "output"))
+	}
+	return b.String()
+}
+
+func escapeDOT(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+func escapeMermaid(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(s)
+}
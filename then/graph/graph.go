@@ -0,0 +1,153 @@
+// Package graph builds pipelines of result functions as data structures.
+//
+// Pipelines composed with package then are opaque closures. The combinators in
+// this package mirror those of package then but additionally record the shape
+// of the pipeline as a tree of `Node`s, which can be rendered as text,
+// Graphviz DOT or Mermaid while the pipeline can still be executed as usual.
+package graph
+
+import (
+	"reflect"
+
+	"github.com/kdungs/go-result/then"
+)
+
+// Kind is the kind of composition a `Node` represents.
+type Kind string
+
+const (
+	// KindStage is a leaf wrapping a single function.
+	KindStage Kind = "stage"
+	KindChain Kind = "chain"
+	KindMap   Kind = "map"
+	KindDo    Kind = "do"
+	KindZip   Kind = "zip"
+	KindMerge Kind = "merge"
+)
+
+// Node describes a (part of a) pipeline.
+type Node struct {
+	Kind Kind
+	// Name is the name of the function wrapped by a stage. It is empty for
+	// compositions.
+	Name string
+	// In holds the names of the input types; binary functions have two.
+	In []string
+	// Out is the name of the output type. It is empty for consumers.
+	Out string
+	// Children are the parts of a composition in the order in which they are
+	// applied. Stages have no children.
+	Children []*Node
+}
+
+func typeName[T any]() string {
+	return reflect.TypeFor[T]().String()
+}
+
+func stage(name string, in []string, out string) *Node {
+	return &Node{Kind: KindStage, Name: name, In: in, Out: out}
+}
+
+func compose(kind Kind, in []string, out string, children ...*Node) *Node {
+	return &Node{Kind: kind, In: in, Out: out, Children: children}
+}
+
+// Pipe is a result function from A to B together with its description.
+type Pipe[A, B any] struct {
+	*Node
+	fn then.FN[A, B]
+}
+
+// FN returns the result function described by `p`.
+func (p Pipe[A, B]) FN() then.FN[A, B] {
+	return p.fn
+}
+
+// Pipe2 is a binary result function together with its description.
+type Pipe2[A, C, E any] struct {
+	*Node
+	fn func(A, C) (E, error)
+}
+
+// Func returns the binary result function described by `p`.
+func (p Pipe2[A, C, E]) Func() func(A, C) (E, error) {
+	return p.fn
+}
+
+// Sink is a consuming function that returns an error together with its
+// description.
+type Sink[A any] struct {
+	*Node
+	fn then.FE[A]
+}
+
+// FE returns the consuming function described by `s`.
+func (s Sink[A]) FE() then.FE[A] {
+	return s.fn
+}
+
+// Sink2 is a binary consuming function that returns an error together with its
+// description.
+type Sink2[A, C any] struct {
+	*Node
+	fn func(A, C) error
+}
+
+// Func returns the binary consuming function described by `s`.
+func (s Sink2[A, C]) Func() func(A, C) error {
+	return s.fn
+}
+
+// Stage describes the result function `f` as a leaf called `name`.
+func Stage[A, B any](name string, f then.FN[A, B]) Pipe[A, B] {
+	return Pipe[A, B]{stage(name, []string{typeName[A]()}, typeName[B]()), f}
+}
+
+// Lift is `then.Lift` for pipelines.
+func Lift[A, B any](name string, f then.F[A, B]) Pipe[A, B] {
+	return Stage(name, then.Lift(f))
+}
+
+// Chain is `then.Chain` for pipelines.
+func Chain[A, B, C any](p Pipe[A, B], q Pipe[B, C]) Pipe[A, C] {
+	return Pipe[A, C]{
+		compose(KindChain, p.In, q.Out, p.Node, q.Node),
+		then.Chain(p.fn, q.fn),
+	}
+}
+
+// Map is `then.Map` for pipelines. The function `g` is called `name`.
+func Map[A, B, C any](name string, p Pipe[A, B], g then.F[B, C]) Pipe[A, C] {
+	n := stage(name, []string{p.Out}, typeName[C]())
+	return Pipe[A, C]{
+		compose(KindMap, p.In, n.Out, p.Node, n),
+		then.Map(p.fn, g),
+	}
+}
+
+// Do is `then.Do` for pipelines. The function `g` is called `name`.
+func Do[A, B any](name string, p Pipe[A, B], g then.FE[B]) Sink[A] {
+	n := stage(name, []string{p.Out}, "")
+	return Sink[A]{
+		compose(KindDo, p.In, "", p.Node, n),
+		then.Do(p.fn, g),
+	}
+}
+
+// Zip is `then.Zip` for pipelines. The function `with` is called `name`.
+func Zip[A, B, C, D, E any](name string, p Pipe[A, B], q Pipe[C, D], with func(B, D) (E, error)) Pipe2[A, C, E] {
+	n := stage(name, []string{p.Out, q.Out}, typeName[E]())
+	return Pipe2[A, C, E]{
+		compose(KindZip, []string{p.In[0], q.In[0]}, n.Out, p.Node, q.Node, n),
+		then.Zip(p.fn, q.fn, with),
+	}
+}
+
+// Merge is `then.Merge` for pipelines. The function `with` is called `name`.
+func Merge[A, B, C, D any](name string, p Pipe[A, B], q Pipe[C, D], with func(B, D) error) Sink2[A, C] {
+	n := stage(name, []string{p.Out, q.Out}, "")
+	return Sink2[A, C]{
+		compose(KindMerge, []string{p.In[0], q.In[0]}, "", p.Node, q.Node, n),
+		then.Merge(p.fn, q.fn, with),
+	}
+}
@@ -0,0 +1,130 @@
+package graph_test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kdungs/go-result/then/graph"
+)
+
+type Doc struct{ Words []string }
+
+func parse(s string) (Doc, error) {
+	if s == "" {
+		return Doc{}, errors.New("empty")
+	}
+	return Doc{Words: strings.Fields(s)}, nil
+}
+
+func count(d Doc) int { return len(d.Words) }
+
+func pipeline() graph.Pipe2[string, string, string] {
+	words := graph.Map("count", graph.Stage("parse", parse), count)
+	return graph.Zip(
+		"compare",
+		words,
+		graph.Chain(graph.Lift("trim", strings.TrimSpace), graph.Stage("atoi", strconv.Atoi)),
+		func(n, m int) (string, error) { return fmt.Sprintf("%d/%d", n, m), nil },
+	)
+}
+
+func TestPipeExecutes(t *testing.T) {
+	f := pipeline().Func()
+	cases := []struct {
+		name        string
+		a, b        string
+		expectedErr string
+		expectedVal string
+	}{
+		{name: "value", a: "a b c", b: " 4 ", expectedVal: "3/4"},
+		{name: "error", a: "", b: "4", expectedErr: "empty"},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := f(tc.a, tc.b)
+			if tc.expectedErr != "" {
+				if err == nil || err.Error() != tc.expectedErr {
+					t.Fatalf("got %v, want %v", err, tc.expectedErr)
+				}
+				return
+			}
+			if err != nil || v != tc.expectedVal {
+				t.Fatalf("got (%q, %v), want (%q, nil)", v, err, tc.expectedVal)
+			}
+		})
+	}
+}
+
+func TestSink(t *testing.T) {
+	var seen []int
+	s := graph.Do("record", graph.Lift("count", count), func(n int) error {
+		seen = append(seen, n)
+		return nil
+	})
+	if err := s.FE()(Doc{Words: []string{"x"}}); err != nil || len(seen) != 1 || seen[0] != 1 {
+		t.Fatalf("got %v with %v, want nil with [1]", err, seen)
+	}
+	if sig := s.Signature(); sig != "graph_test.Doc -> error" {
+		t.Fatalf("got %q, want %q", sig, "graph_test.Doc -> error")
+	}
+}
+
+func ExampleNode_Explain() {
+	fmt.Print(pipeline().Explain())
+	// Output:
+	// zip: (string, string) -> string
+	//   map: string -> int
+	//     stage "parse": string -> graph_test.Doc
+	//     stage "count": graph_test.Doc -> int
+	//   chain: string -> int
+	//     stage "trim": string -> string
+	//     stage "atoi": string -> int
+	//   stage "compare": (int, int) -> string
+}
+
+func ExampleNode_DOT() {
+	fmt.Print(pipeline().DOT())
+	// Output:
+	// digraph pipeline {
+	// 	rankdir=LR;
+	// 	node [shape=box];
+	// 	input [shape=circle, label="in"];
+	// 	output [shape=circle, label="out"];
+	// 	n0 [label="parse", tooltip="string -> graph_test.Doc"];
+	// 	n1 [label="count", tooltip="graph_test.Doc -> int"];
+	// 	n2 [label="trim", tooltip="string -> string"];
+	// 	n3 [label="atoi", tooltip="string -> int"];
+	// 	n4 [label="compare", tooltip="(int, int) -> string"];
+	// 	n0 -> n1 [label="graph_test.Doc"];
+	// 	n2 -> n3 [label="string"];
+	// 	n1 -> n4 [label="int"];
+	// 	n3 -> n4 [label="int"];
+	// 	input -> n0 [label="string"];
+	// 	input -> n2 [label="string"];
+	// 	n4 -> output [label="string"];
+	// }
+}
+
+func ExampleNode_Mermaid() {
+	p := graph.Merge(
+		"store",
+		graph.Stage("parse", parse),
+		graph.Lift("quote", strconv.Quote),
+		func(Doc, string) error { return nil },
+	)
+	fmt.Print(p.Mermaid())
+	// Output:
+	// flowchart LR
+	// 	input((in))
+	// 	n0["parse"]
+	// 	n1["quote"]
+	// 	n2["store"]
+	// 	n0 -->|"graph_test.Doc"| n2
+	// 	n1 -->|"string"| n2
+	// 	input -->|"string"| n0
+	// 	input -->|"string"| n1
+}
@@ -0,0 +1,70 @@
+package then
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Hedge calls `f` and, if it has not returned after `delay`, starts another
+// call with the same input, up to `maxHedges` extra calls. A failed call also
+// starts the next one right away. The first successful result wins. If all
+// calls fail, their errors are joined.
+// Since `f` takes no context, calls that lose the race cannot be cancelled and
+// run to completion in the background. Use `HedgeC` to cancel them.
+func Hedge[A, B any](f FN[A, B], delay time.Duration, maxHedges int) FN[A, B] {
+	return Bind(context.Background(), HedgeC(Ctx(f), delay, maxHedges))
+}
+
+// HedgeC is `Hedge` for context-aware result functions. The contexts of calls
+// that lose the race are cancelled. Delays are measured with the `Clock` of
+// the context.
+func HedgeC[A, B any](f FC[A, B], delay time.Duration, maxHedges int) FC[A, B] {
+	maxHedges = max(maxHedges, 0)
+	return func(ctx context.Context, a A) (B, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		type res struct {
+			b   B
+			err error
+		}
+		// Buffered so that calls that lose the race never block.
+		results := make(chan res, maxHedges+1)
+		clock := ClockFrom(ctx)
+		started, pending := 0, 0
+		var next <-chan time.Time
+		launch := func() {
+			started++
+			pending++
+			go func() {
+				b, err := f(ctx, a)
+				results <- res{b, err}
+			}()
+			next = nil
+			if started <= maxHedges {
+				next = clock.After(delay)
+			}
+		}
+		launch()
+		var errs []error
+		for {
+			select {
+			case r := <-results:
+				pending--
+				if r.err == nil {
+					return r.b, nil
+				}
+				errs = append(errs, r.err)
+				if started <= maxHedges {
+					launch()
+				} else if pending == 0 {
+					return *new(B), errors.Join(errs...)
+				}
+			case <-next:
+				launch()
+			case <-ctx.Done():
+				return *new(B), context.Cause(ctx)
+			}
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package then_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kdungs/go-result/then"
+	"github.com/kdungs/go-result/then/thentest"
+)
+
+func TestHedge(t *testing.T) {
+	clock := thentest.NewClock()
+	ctx := then.WithClock(context.Background(), clock)
+	var calls atomic.Int32
+	cancelled := make(chan struct{})
+	f := then.HedgeC(func(ctx context.Context, x int) (int, error) {
+		if calls.Add(1) == 1 {
+			<-ctx.Done()
+			close(cancelled)
+			return 0, ctx.Err()
+		}
+		return x, nil
+	}, time.Second, 2)
+
+	done := make(chan error)
+	go func() {
+		v, err := f(ctx, 42)
+		if err == nil && v != 42 {
+			err = fmt.Errorf("got %d, want 42", v)
+		}
+		done <- err
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	if err := <-done; err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	<-cancelled
+	if c := calls.Load(); c != 2 {
+		t.Fatalf("got %d calls, want 2", c)
+	}
+}
+
+func TestHedgeFast(t *testing.T) {
+	ctx := then.WithClock(context.Background(), thentest.NewClock())
+	calls := 0
+	f := then.HedgeC(then.Ctx(func(x int) (int, error) {
+		calls++
+		return x, nil
+	}), time.Second, 2)
+	if v, err := f(ctx, 42); err != nil || v != 42 {
+		t.Fatalf("got (%d, %v), want (42, nil)", v, err)
+	}
+	if calls != 1 {
+		t.Fatalf("got %d calls, want 1", calls)
+	}
+}
+
+func TestHedgeAllFail(t *testing.T) {
+	ctx := then.WithClock(context.Background(), thentest.NewClock())
+	var errs []error
+	for i := 0; i < 3; i++ {
+		errs = append(errs, fmt.Errorf("attempt %d", i))
+	}
+	var calls atomic.Int32
+	f := then.HedgeC(then.Ctx(func(int) (int, error) {
+		return 0, errs[calls.Add(1)-1]
+	}), time.Second, 2)
+	// Failed attempts start the next one without waiting for the delay.
+	_, err := f(ctx, 42)
+	for _, e := range errs {
+		if !errors.Is(err, e) {
+			t.Fatalf("got %v, want it to contain %v", err, e)
+		}
+	}
+	if c := calls.Load(); c != 3 {
+		t.Fatalf("got %d calls, want 3", c)
+	}
+}
+
+func TestHedgeFN(t *testing.T) {
+	var calls atomic.Int32
+	second := make(chan struct{})
+	slow := func(x int) (int, error) {
+		if calls.Add(1) == 1 {
+			// The first call only returns once it lost the race.
+			<-second
+			return 0, errors.New("too late")
+		}
+		defer close(second)
+		return x, nil
+	}
+	f := then.Chain(then.Hedge(slow, time.Millisecond, 1), then.Lift(func(x int) string {
+		return fmt.Sprint(x)
+	}))
+	if v, err := f(42); err != nil || v != "42" {
+		t.Fatalf("got (%q, %v), want (%q, nil)", v, err, "42")
+	}
+}
+
+func TestHedgeNegativeMax(t *testing.T) {
+	calls := 0
+	f := then.Hedge(func(x int) (int, error) {
+		calls++
+		return 0, errors.New("f")
+	}, time.Second, -5)
+	if _, err := f(42); err == nil {
+		t.Fatalf("got nil, want error")
+	}
+	if calls != 1 {
+		t.Fatalf("got %d calls, want 1", calls)
+	}
+}
@@ -0,0 +1,220 @@
+package then
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter decides when a call may proceed. `Acquire` blocks until the call
+// may proceed or `ctx` is done. On success, the caller must call `release`
+// once the call has finished.
+type Limiter interface {
+	Acquire(ctx context.Context) (release func(), err error)
+}
+
+// Limit guards `f` with `l`. The same limiter can guard several functions of
+// different kinds (see `LimitE`, `LimitZip` and `LimitMerge`), e.g. all calls
+// to a shared backend.
+func Limit[A, B any](l Limiter, f FN[A, B]) FC[A, B] {
+	return LimitC(l, Ctx(f))
+}
+
+// LimitC is `Limit` for context-aware result functions.
+func LimitC[A, B any](l Limiter, f FC[A, B]) FC[A, B] {
+	return func(ctx context.Context, a A) (B, error) {
+		release, err := l.Acquire(ctx)
+		if err != nil {
+			return *new(B), err
+		}
+		defer release()
+		return f(ctx, a)
+	}
+}
+
+// LimitE guards a consuming function that returns an error with `l`.
+func LimitE[A any](l Limiter, f FE[A]) func(context.Context, A) error {
+	return func(ctx context.Context, a A) error {
+		release, err := l.Acquire(ctx)
+		if err != nil {
+			return err
+		}
+		defer release()
+		return f(a)
+	}
+}
+
+// LimitZip guards a binary result function, e.g. one returned by `Zip`, with
+// `l`.
+func LimitZip[A, C, E any](l Limiter, f func(A, C) (E, error)) func(context.Context, A, C) (E, error) {
+	return func(ctx context.Context, a A, c C) (E, error) {
+		release, err := l.Acquire(ctx)
+		if err != nil {
+			return *new(E), err
+		}
+		defer release()
+		return f(a, c)
+	}
+}
+
+// LimitMerge guards a binary consuming function, e.g. one returned by `Merge`,
+// with `l`.
+func LimitMerge[A, C any](l Limiter, f func(A, C) error) func(context.Context, A, C) error {
+	return func(ctx context.Context, a A, c C) error {
+		release, err := l.Acquire(ctx)
+		if err != nil {
+			return err
+		}
+		defer release()
+		return f(a, c)
+	}
+}
+
+// RateLimit limits calls of `f` to `rate` per second with bursts of up to
+// `burst` calls. Calls over the limit wait for their turn unless their
+// context is done first. See `NewRateLimiter` for non-positive rates.
+func RateLimit[A, B any](f FN[A, B], rate float64, burst int) FC[A, B] {
+	return Limit(NewRateLimiter(rate, burst), f)
+}
+
+// Bulkhead limits the number of concurrent calls of `f` to `maxConcurrent`.
+// Up to `maxQueue` further calls wait for a free slot; any more are rejected
+// with a `*BulkheadFullError`.
+func Bulkhead[A, B any](f FN[A, B], maxConcurrent, maxQueue int) FC[A, B] {
+	return Limit(NewBulkheadLimiter(maxConcurrent, maxQueue), f)
+}
+
+func noRelease() {}
+
+// RateLimiter is a token bucket `Limiter`. It reads the time from the `Clock`
+// in the context passed to `Acquire`.
+type RateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a `RateLimiter` that allows `rate` calls per second
+// with bursts of up to `burst` calls. A `burst` below 1 is treated as 1.
+//
+// If `rate` is not positive, the bucket is never refilled: the first `burst`
+// calls proceed and all later calls wait until their context is done.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	b := math.Max(float64(burst), 1)
+	return &RateLimiter{rate: rate, burst: b, tokens: b}
+}
+
+// Acquire takes a token from the bucket, waiting for one to become available
+// if necessary.
+func (l *RateLimiter) Acquire(ctx context.Context) (func(), error) {
+	if ctx.Err() != nil {
+		return nil, context.Cause(ctx)
+	}
+	clock := ClockFrom(ctx)
+	l.mu.Lock()
+	now := clock.Now()
+	if l.last.IsZero() {
+		l.last = now
+	}
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+elapsed.Seconds()*l.rate)
+		l.last = now
+	}
+	// Reserve the token right away so that waiting calls are served in order.
+	l.tokens--
+	missing := -l.tokens
+	l.mu.Unlock()
+	if missing <= 0 {
+		return noRelease, nil
+	}
+	var ready <-chan time.Time
+	if l.rate > 0 {
+		ready = clock.After(time.Duration(missing / l.rate * float64(time.Second)))
+	}
+	select {
+	case <-ready:
+		return noRelease, nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return nil, context.Cause(ctx)
+	}
+}
+
+// BulkheadFullError is returned when a bulkhead rejects a call because all
+// of its slots and its queue are taken.
+type BulkheadFullError struct {
+	MaxConcurrent int
+	MaxQueue      int
+}
+
+func (e *BulkheadFullError) Error() string {
+	return fmt.Sprintf("bulkhead full: %d running, %d queued", e.MaxConcurrent, e.MaxQueue)
+}
+
+// testHookBulkheadQueued, if not nil, is called whenever a call starts
+// waiting in the queue of a bulkhead.
+var testHookBulkheadQueued func()
+
+// BulkheadLimiter is a `Limiter` that bounds the number of concurrent and
+// waiting calls.
+type BulkheadLimiter struct {
+	slots    chan struct{}
+	maxQueue int
+
+	mu     sync.Mutex
+	queued int
+}
+
+// NewBulkheadLimiter returns a `BulkheadLimiter` with `maxConcurrent` slots
+// and room for `maxQueue` waiting calls. A `maxConcurrent` below 1 is treated
+// as 1.
+func NewBulkheadLimiter(maxConcurrent, maxQueue int) *BulkheadLimiter {
+	return &BulkheadLimiter{
+		slots:    make(chan struct{}, max(maxConcurrent, 1)),
+		maxQueue: maxQueue,
+	}
+}
+
+// Acquire takes a slot, waiting in the queue if there is room.
+func (l *BulkheadLimiter) Acquire(ctx context.Context) (func(), error) {
+	if ctx.Err() != nil {
+		return nil, context.Cause(ctx)
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	default:
+	}
+	l.mu.Lock()
+	if l.queued >= l.maxQueue {
+		l.mu.Unlock()
+		return nil, &BulkheadFullError{MaxConcurrent: cap(l.slots), MaxQueue: l.maxQueue}
+	}
+	l.queued++
+	l.mu.Unlock()
+	if testHookBulkheadQueued != nil {
+		testHookBulkheadQueued()
+	}
+	defer func() {
+		l.mu.Lock()
+		l.queued--
+		l.mu.Unlock()
+	}()
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	}
+}
+
+func (l *BulkheadLimiter) release() {
+	<-l.slots
+}
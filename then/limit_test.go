@@ -0,0 +1,150 @@
+package then_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kdungs/go-result/then"
+	"github.com/kdungs/go-result/then/thentest"
+)
+
+func TestRateLimit(t *testing.T) {
+	clock := thentest.NewClock()
+	ctx := then.WithClock(context.Background(), clock)
+	f := then.RateLimit(func(x int) (int, error) { return x, nil }, 1, 2)
+	for i := 0; i < 2; i++ {
+		if _, err := f(ctx, i); err != nil {
+			t.Fatalf("burst call %d: got %v, want nil", i, err)
+		}
+	}
+
+	// Cancelling a waiting call gives back its reservation.
+	cctx, cancel := context.WithCancel(ctx)
+	errc := make(chan error)
+	go func() {
+		_, err := f(cctx, 2)
+		errc <- err
+	}()
+	clock.BlockUntil(1)
+	cancel()
+	if err := <-errc; err != context.Canceled {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+
+	go func() {
+		_, err := f(ctx, 3)
+		errc <- err
+	}()
+	clock.BlockUntil(2)
+	clock.Advance(time.Second)
+	if err := <-errc; err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	clock.Advance(time.Second)
+	if _, err := f(ctx, 4); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+}
+
+func TestRateLimitZeroRate(t *testing.T) {
+	clock := thentest.NewClock()
+	ctx := then.WithClock(context.Background(), clock)
+	f := then.RateLimit(func(x int) (int, error) { return x, nil }, 0, 1)
+	if _, err := f(ctx, 0); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	clock.Advance(time.Hour)
+	tctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := f(tctx, 1); err != context.DeadlineExceeded {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestBulkhead(t *testing.T) {
+	ctx := context.Background()
+	queued := make(chan struct{}, 1)
+	t.Cleanup(then.SetBulkheadQueuedHook(func() { queued <- struct{}{} }))
+	release := make(chan struct{})
+	started := make(chan int, 2)
+	f := then.Bulkhead(func(x int) (int, error) {
+		started <- x
+		<-release
+		return x, nil
+	}, 1, 1)
+	done := make(chan error, 2)
+	go func() {
+		_, err := f(ctx, 0)
+		done <- err
+	}()
+	if x := <-started; x != 0 {
+		t.Fatalf("got call %d, want 0", x)
+	}
+	go func() {
+		_, err := f(ctx, 1)
+		done <- err
+	}()
+	<-queued
+	select {
+	case x := <-started:
+		t.Fatalf("got call %d running, want it queued", x)
+	default:
+	}
+
+	var full *then.BulkheadFullError
+	if _, err := f(ctx, 2); !errors.As(err, &full) || full.MaxConcurrent != 1 || full.MaxQueue != 1 {
+		t.Fatalf("got %v, want *BulkheadFullError", err)
+	}
+
+	close(release)
+	if x := <-started; x != 1 {
+		t.Fatalf("got call %d, want 1", x)
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-done; err != nil {
+			t.Fatalf("got %v, want nil", err)
+		}
+	}
+}
+
+func TestBulkheadShared(t *testing.T) {
+	ctx := context.Background()
+	l := then.NewBulkheadLimiter(1, 0)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	f := then.Limit(l, func(x int) (int, error) {
+		close(started)
+		<-release
+		return x, nil
+	})
+	done := make(chan error)
+	go func() {
+		_, err := f(ctx, 0)
+		done <- err
+	}()
+	<-started
+
+	var full *then.BulkheadFullError
+	errE := then.LimitE(l, func(int) error { return nil })(ctx, 1)
+	if !errors.As(errE, &full) {
+		t.Fatalf("got %v, want *BulkheadFullError", errE)
+	}
+	errM := then.LimitMerge(l, func(int, string) error { return nil })(ctx, 1, "a")
+	if !errors.As(errM, &full) {
+		t.Fatalf("got %v, want *BulkheadFullError", errM)
+	}
+	_, errZ := then.LimitZip(l, func(a int, b string) (int, error) { return a, nil })(ctx, 1, "a")
+	if !errors.As(errZ, &full) || full.MaxConcurrent != 1 || full.MaxQueue != 0 {
+		t.Fatalf("got %v, want *BulkheadFullError", errZ)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if err := then.LimitE(l, func(int) error { return nil })(ctx, 1); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+}
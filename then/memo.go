@@ -0,0 +1,150 @@
+package then
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+)
+
+// MemoConfig configures `Memo`.
+type MemoConfig struct {
+	// Size bounds the number of cached entries. When the cache is full, the
+	// least recently used entry is evicted. Zero means unbounded.
+	Size int
+	// TTL is how long a value stays cached. Zero means forever.
+	TTL time.Duration
+	// ErrorTTL is how long an error stays cached. Zero means errors are not
+	// cached at all, so failed calls are retried.
+	ErrorTTL time.Duration
+	// Clock is used to expire entries. If nil, `SystemClock` is used.
+	Clock Clock
+}
+
+// Memo caches the results of `f` by input. Concurrent calls with the same
+// uncached input all call `f`; wrap `f` in `SingleFlight` to avoid that.
+func Memo[K comparable, V any](f FN[K, V], cfg MemoConfig) FN[K, V] {
+	if cfg.Clock == nil {
+		cfg.Clock = SystemClock
+	}
+	c := &memoCache[K, V]{
+		cfg:     cfg,
+		entries: make(map[K]*list.Element),
+		lru:     list.New(),
+	}
+	return func(k K) (V, error) {
+		if v, err, ok := c.get(k); ok {
+			return v, err
+		}
+		v, err := f(k)
+		c.put(k, v, err)
+		return v, err
+	}
+}
+
+type memoEntry[K comparable, V any] struct {
+	k       K
+	v       V
+	err     error
+	expires time.Time
+}
+
+type memoCache[K comparable, V any] struct {
+	cfg MemoConfig
+
+	mu      sync.Mutex
+	entries map[K]*list.Element
+	lru     *list.List
+}
+
+func (c *memoCache[K, V]) get(k K) (V, error, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[k]
+	if !ok {
+		return *new(V), nil, false
+	}
+	e := el.Value.(*memoEntry[K, V])
+	if !e.expires.IsZero() && !c.cfg.Clock.Now().Before(e.expires) {
+		c.lru.Remove(el)
+		delete(c.entries, k)
+		return *new(V), nil, false
+	}
+	c.lru.MoveToFront(el)
+	return e.v, e.err, true
+}
+
+func (c *memoCache[K, V]) put(k K, v V, err error) {
+	ttl := c.cfg.TTL
+	if err != nil {
+		if c.cfg.ErrorTTL == 0 {
+			return
+		}
+		ttl = c.cfg.ErrorTTL
+	}
+	e := &memoEntry[K, V]{k: k, v: v, err: err}
+	if ttl > 0 {
+		e.expires = c.cfg.Clock.Now().Add(ttl)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[k]; ok {
+		el.Value = e
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[k] = c.lru.PushFront(e)
+	if c.cfg.Size > 0 && c.lru.Len() > c.cfg.Size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoEntry[K, V]).k)
+	}
+}
+
+// ErrSingleFlightPanic is returned to callers of a `SingleFlight` function
+// that joined a call which panicked. The caller that made the call panics.
+var ErrSingleFlightPanic = errors.New("then: shared call panicked")
+
+// testHookSingleFlightJoin, if not nil, is called whenever a caller joins a
+// call in flight.
+var testHookSingleFlightJoin func()
+
+// SingleFlight collapses concurrent calls of `f` with the same input into a
+// single call whose result is shared by all callers.
+func SingleFlight[K comparable, V any](f FN[K, V]) FN[K, V] {
+	type call struct {
+		done chan struct{}
+		v    V
+		err  error
+	}
+	var mu sync.Mutex
+	calls := make(map[K]*call)
+	return func(k K) (V, error) {
+		mu.Lock()
+		if c, ok := calls[k]; ok {
+			mu.Unlock()
+			if testHookSingleFlightJoin != nil {
+				testHookSingleFlightJoin()
+			}
+			<-c.done
+			return c.v, c.err
+		}
+		c := &call{done: make(chan struct{})}
+		calls[k] = c
+		mu.Unlock()
+
+		panicked := true
+		defer func() {
+			if panicked {
+				c.err = ErrSingleFlightPanic
+			}
+			mu.Lock()
+			delete(calls, k)
+			mu.Unlock()
+			close(c.done)
+		}()
+		c.v, c.err = f(k)
+		panicked = false
+		return c.v, c.err
+	}
+}
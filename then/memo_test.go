@@ -0,0 +1,193 @@
+package then_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kdungs/go-result/then"
+	"github.com/kdungs/go-result/then/thentest"
+)
+
+func TestMemo(t *testing.T) {
+	errF := errors.New("f")
+	type call struct {
+		advance time.Duration
+		k       int
+		cached  bool
+	}
+	cases := []struct {
+		name  string
+		cfg   then.MemoConfig
+		fail  bool
+		calls []call
+	}{
+		{
+			name: "unbounded",
+			calls: []call{
+				{k: 1},
+				{k: 2},
+				{k: 1, cached: true},
+				{advance: time.Hour, k: 2, cached: true},
+			},
+		},
+		{
+			name: "least recently used is evicted",
+			cfg:  then.MemoConfig{Size: 2},
+			calls: []call{
+				{k: 1},
+				{k: 2},
+				{k: 1, cached: true},
+				{k: 3},
+				{k: 1, cached: true},
+				{k: 2},
+			},
+		},
+		{
+			name: "values expire",
+			cfg:  then.MemoConfig{TTL: time.Minute},
+			calls: []call{
+				{k: 1},
+				{advance: 59 * time.Second, k: 1, cached: true},
+				{advance: time.Second, k: 1},
+			},
+		},
+		{
+			name: "errors are not cached",
+			fail: true,
+			calls: []call{
+				{k: 1},
+				{k: 1},
+			},
+		},
+		{
+			name: "errors are cached",
+			cfg:  then.MemoConfig{ErrorTTL: time.Second},
+			fail: true,
+			calls: []call{
+				{k: 1},
+				{k: 1, cached: true},
+				{advance: time.Second, k: 1},
+			},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			clock := thentest.NewClock()
+			cfg := tc.cfg
+			cfg.Clock = clock
+			calls := 0
+			f := then.Memo(func(k int) (int, error) {
+				calls++
+				if tc.fail {
+					return 0, errF
+				}
+				return k * 10, nil
+			}, cfg)
+			for i, c := range tc.calls {
+				clock.Advance(c.advance)
+				before := calls
+				v, err := f(c.k)
+				if tc.fail && err != errF {
+					t.Fatalf("call %d: got %v, want %v", i, err, errF)
+				}
+				if !tc.fail && (err != nil || v != c.k*10) {
+					t.Fatalf("call %d: got (%d, %v), want (%d, nil)", i, v, err, c.k*10)
+				}
+				if cached := calls == before; cached != c.cached {
+					t.Fatalf("call %d: got cached=%v, want %v", i, cached, c.cached)
+				}
+			}
+		})
+	}
+}
+
+// onSingleFlightJoin returns a channel that is closed once `n` callers have
+// joined a call in flight.
+func onSingleFlightJoin(t *testing.T, n int32) <-chan struct{} {
+	var joins atomic.Int32
+	joined := make(chan struct{})
+	t.Cleanup(then.SetSingleFlightJoinHook(func() {
+		if joins.Add(1) == n {
+			close(joined)
+		}
+	}))
+	return joined
+}
+
+func TestSingleFlight(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	started := make(chan struct{})
+	f := then.SingleFlight(func(k string) (string, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return k + "!", nil
+	})
+
+	const n = 10
+	joined := onSingleFlightJoin(t, n-1)
+	var wg sync.WaitGroup
+	results := make([]string, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i > 0 {
+				<-started
+			}
+			results[i], _ = f("a")
+		}(i)
+	}
+	<-joined
+	close(release)
+	wg.Wait()
+
+	if c := calls.Load(); c != 1 {
+		t.Fatalf("got %d calls, want 1", c)
+	}
+	for i, r := range results {
+		if r != "a!" {
+			t.Fatalf("result %d: got %q, want %q", i, r, "a!")
+		}
+	}
+	if v, _ := f("b"); v != "b!" || calls.Load() != 2 {
+		t.Fatalf("got %q after %d calls, want %q after 2", v, calls.Load(), "b!")
+	}
+}
+
+func TestSingleFlightPanic(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	f := then.SingleFlight(func(k string) (string, error) {
+		close(started)
+		<-release
+		panic("boom")
+	})
+	joined := onSingleFlightJoin(t, 1)
+
+	leader := make(chan any)
+	go func() {
+		defer func() { leader <- recover() }()
+		f("a")
+	}()
+	<-started
+	errc := make(chan error)
+	go func() {
+		_, err := f("a")
+		errc <- err
+	}()
+	<-joined
+	close(release)
+	if p := <-leader; p != "boom" {
+		t.Fatalf("got %v, want %q", p, "boom")
+	}
+	if err := <-errc; err != then.ErrSingleFlightPanic {
+		t.Fatalf("got %v, want %v", err, then.ErrSingleFlightPanic)
+	}
+}
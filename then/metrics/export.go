@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Var returns an `expvar.Var` reporting the `Snapshot` of `r` as JSON.
+func (r *Registry) Var() expvar.Var {
+	return expvar.Func(func() any { return r.Snapshot() })
+}
+
+// Publish publishes `r` via package expvar under `name`. Like
+// `expvar.Publish`, it panics if the name is already taken.
+func (r *Registry) Publish(name string) {
+	expvar.Publish(name, r.Var())
+}
+
+// Handler returns an `http.Handler` serving the metrics of `r` in the
+// Prometheus text exposition format.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.WritePrometheus(w)
+	})
+}
+
+// WritePrometheus writes the metrics of `r` to `w` in the Prometheus text
+// exposition format.
+func (r *Registry) WritePrometheus(w io.Writer) error {
+	snap := r.Snapshot()
+	names := make([]string, 0, len(snap))
+	for n := range snap {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	header := func(metric, typ, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", metric, help, metric, typ)
+	}
+	header("then_stage_calls_total", "counter", "Number of calls of a stage.")
+	for _, n := range names {
+		fmt.Fprintf(&b, "then_stage_calls_total{stage=%s} %d\n", quote(n), snap[n].Calls)
+	}
+	header("then_stage_successes_total", "counter", "Number of successful calls of a stage.")
+	for _, n := range names {
+		fmt.Fprintf(&b, "then_stage_successes_total{stage=%s} %d\n", quote(n), snap[n].Successes)
+	}
+	header("then_stage_failures_total", "counter", "Number of failed calls of a stage by error class.")
+	for _, n := range names {
+		classes := make([]string, 0, len(snap[n].Failures))
+		for c := range snap[n].Failures {
+			classes = append(classes, c)
+		}
+		sort.Strings(classes)
+		for _, c := range classes {
+			fmt.Fprintf(&b, "then_stage_failures_total{stage=%s,class=%s} %d\n", quote(n), quote(c), snap[n].Failures[c])
+		}
+	}
+	header("then_stage_duration_seconds", "histogram", "Latency of calls of a stage.")
+	for _, n := range names {
+		s := snap[n]
+		for i, c := range s.Buckets {
+			le := "+Inf"
+			if i < len(r.cfg.Buckets) {
+				le = strconv.FormatFloat(r.cfg.Buckets[i], 'g', -1, 64)
+			}
+			fmt.Fprintf(&b, "then_stage_duration_seconds_bucket{stage=%s,le=%q} %d\n", quote(n), le, c)
+		}
+		fmt.Fprintf(&b, "then_stage_duration_seconds_sum{stage=%s} %s\n", quote(n), strconv.FormatFloat(s.Sum, 'g', -1, 64))
+		// Counts and buckets are updated separately, so derive the count
+		// from the buckets to keep the histogram consistent.
+		fmt.Fprintf(&b, "then_stage_duration_seconds_count{stage=%s} %d\n", quote(n), s.Buckets[len(s.Buckets)-1])
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// quote quotes a label value as required by the Prometheus text format.
+func quote(v string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v) + `"`
+}
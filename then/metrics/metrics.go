@@ -0,0 +1,180 @@
+// Package metrics collects call counts, failures and latency histograms for
+// named stages of pipelines built with package then and exposes them via
+// package expvar and in the Prometheus text format.
+//
+// Collecting metrics only involves atomic operations on the hot path, so it
+// is cheap enough to leave on in production.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kdungs/go-result/then"
+)
+
+// DefaultBuckets are the default upper bounds of latency histogram buckets in
+// seconds. They are the same as those of the Prometheus client libraries.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Config configures a `Registry`.
+type Config struct {
+	// Buckets are the upper bounds of latency histogram buckets in seconds,
+	// in increasing order. If nil, `DefaultBuckets` is used.
+	Buckets []float64
+	// Classify maps errors to a small set of classes by which failures are
+	// counted. If nil, `Classify` is used.
+	Classify func(error) string
+	// Clock is used to measure latencies. If nil, `then.SystemClock` is used.
+	Clock then.Clock
+}
+
+// Classify is the default error classifier. It knows about the errors of
+// package then and of package context and maps all other errors to "error".
+func Classify(err error) string {
+	var full *then.BulkheadFullError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, then.ErrOpen):
+		return "breaker_open"
+	case errors.As(err, &full):
+		return "bulkhead_full"
+	}
+	return "error"
+}
+
+// Registry holds the metrics of all stages wrapped with it.
+type Registry struct {
+	cfg Config
+
+	mu     sync.Mutex
+	stages map[string]*stage
+}
+
+// NewRegistry returns an empty `Registry`.
+func NewRegistry(cfg Config) *Registry {
+	if cfg.Buckets == nil {
+		cfg.Buckets = DefaultBuckets
+	}
+	if cfg.Classify == nil {
+		cfg.Classify = Classify
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = then.SystemClock
+	}
+	return &Registry{cfg: cfg, stages: make(map[string]*stage)}
+}
+
+type stage struct {
+	calls     atomic.Uint64
+	successes atomic.Uint64
+	failures  sync.Map // class -> *atomic.Uint64
+	// buckets holds one counter per bucket plus one for +Inf. Counts are
+	// not cumulative.
+	buckets []atomic.Uint64
+	sumNS   atomic.Int64
+}
+
+// stage returns the metrics of the stage `name`, creating them if necessary.
+// Stages wrapped several times under the same name share their metrics.
+func (r *Registry) stage(name string) *stage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.stages[name]
+	if !ok {
+		s = &stage{buckets: make([]atomic.Uint64, len(r.cfg.Buckets)+1)}
+		r.stages[name] = s
+	}
+	return s
+}
+
+func (r *Registry) observe(s *stage, d time.Duration, err error) {
+	s.calls.Add(1)
+	if err == nil {
+		s.successes.Add(1)
+	} else {
+		class := r.cfg.Classify(err)
+		c, ok := s.failures.Load(class)
+		if !ok {
+			c, _ = s.failures.LoadOrStore(class, new(atomic.Uint64))
+		}
+		c.(*atomic.Uint64).Add(1)
+	}
+	s.sumNS.Add(int64(d))
+	secs := d.Seconds()
+	i := sort.SearchFloat64s(r.cfg.Buckets, secs)
+	s.buckets[i].Add(1)
+}
+
+// Stage collects metrics for `f` under the name `name`.
+func Stage[A, B any](r *Registry, name string, f then.FN[A, B]) then.FN[A, B] {
+	s := r.stage(name)
+	return func(a A) (B, error) {
+		start := r.cfg.Clock.Now()
+		b, err := f(a)
+		r.observe(s, r.cfg.Clock.Now().Sub(start), err)
+		return b, err
+	}
+}
+
+// StageC is `Stage` for context-aware result functions.
+func StageC[A, B any](r *Registry, name string, f then.FC[A, B]) then.FC[A, B] {
+	s := r.stage(name)
+	return func(ctx context.Context, a A) (B, error) {
+		start := r.cfg.Clock.Now()
+		b, err := f(ctx, a)
+		r.observe(s, r.cfg.Clock.Now().Sub(start), err)
+		return b, err
+	}
+}
+
+// StageSnapshot holds the metrics of a stage at a point in time.
+type StageSnapshot struct {
+	Calls     uint64            `json:"calls"`
+	Successes uint64            `json:"successes"`
+	Failures  map[string]uint64 `json:"failures"`
+	// Buckets holds the cumulative number of calls that took at most the
+	// corresponding element of `Config.Buckets`; the last element counts all
+	// calls.
+	Buckets []uint64 `json:"buckets"`
+	// Sum is the total time spent in the stage in seconds.
+	Sum float64 `json:"sum"`
+}
+
+// Snapshot returns the current metrics of all stages by name.
+func (r *Registry) Snapshot() map[string]StageSnapshot {
+	r.mu.Lock()
+	stages := make(map[string]*stage, len(r.stages))
+	for n, s := range r.stages {
+		stages[n] = s
+	}
+	r.mu.Unlock()
+	snap := make(map[string]StageSnapshot, len(stages))
+	for n, s := range stages {
+		ss := StageSnapshot{
+			Calls:     s.calls.Load(),
+			Successes: s.successes.Load(),
+			Failures:  make(map[string]uint64),
+			Buckets:   make([]uint64, len(s.buckets)),
+			Sum:       time.Duration(s.sumNS.Load()).Seconds(),
+		}
+		s.failures.Range(func(k, v any) bool {
+			ss.Failures[k.(string)] = v.(*atomic.Uint64).Load()
+			return true
+		})
+		var cum uint64
+		for i := range s.buckets {
+			cum += s.buckets[i].Load()
+			ss.Buckets[i] = cum
+		}
+		snap[n] = ss
+	}
+	return snap
+}
@@ -0,0 +1,162 @@
+package metrics_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kdungs/go-result/then"
+	"github.com/kdungs/go-result/then/metrics"
+	"github.com/kdungs/go-result/then/thentest"
+)
+
+// slow returns a stage that advances `clock` by `d` and fails with `err` if
+// the input is negative.
+func slow(clock *thentest.Clock, d time.Duration, err error) then.FN[int, int] {
+	return func(x int) (int, error) {
+		clock.Advance(d)
+		if x < 0 {
+			return 0, err
+		}
+		return x, nil
+	}
+}
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{name: "deadline", err: context.DeadlineExceeded, expected: "timeout"},
+		{name: "timeout", err: &then.TimeoutError{}, expected: "timeout"},
+		{name: "wrapped cancel", err: fmt.Errorf("wrapped: %w", context.Canceled), expected: "canceled"},
+		{name: "breaker", err: then.ErrOpen, expected: "breaker_open"},
+		{name: "bulkhead", err: &then.BulkheadFullError{}, expected: "bulkhead_full"},
+		{name: "other", err: errors.New("other"), expected: "error"},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := metrics.Classify(tc.err); got != tc.expected {
+				t.Fatalf("got %q, want %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestStage(t *testing.T) {
+	clock := thentest.NewClock()
+	reg := metrics.NewRegistry(metrics.Config{
+		Buckets: []float64{0.1, 1},
+		Clock:   clock,
+	})
+	f := metrics.Stage(reg, "parse", slow(clock, 500*time.Millisecond, context.DeadlineExceeded))
+	g := metrics.StageC(reg, "fetch", then.Ctx(slow(clock, 50*time.Millisecond, errors.New("fetch"))))
+
+	for _, x := range []int{1, -1, 2, -2} {
+		f(x)
+	}
+	f = metrics.Stage(reg, "parse", slow(clock, 2*time.Second, nil))
+	f(3)
+	g(context.Background(), -1)
+
+	expected := map[string]metrics.StageSnapshot{
+		"parse": {
+			Calls:     5,
+			Successes: 3,
+			Failures:  map[string]uint64{"timeout": 2},
+			Buckets:   []uint64{0, 4, 5},
+			Sum:       4,
+		},
+		"fetch": {
+			Calls:     1,
+			Successes: 0,
+			Failures:  map[string]uint64{"error": 1},
+			Buckets:   []uint64{1, 1, 1},
+			Sum:       0.05,
+		},
+	}
+	if got := reg.Snapshot(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("got %+v, want %+v", got, expected)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	clock := thentest.NewClock()
+	reg := metrics.NewRegistry(metrics.Config{
+		Buckets:  []float64{0.25, 1},
+		Classify: func(error) string { return "bad" },
+		Clock:    clock,
+	})
+	f := metrics.Stage(reg, `say "hi"`, slow(clock, 500*time.Millisecond, errors.New("f")))
+	f(1)
+	f(-1)
+
+	srv := httptest.NewServer(reg.Handler())
+	defer srv.Close()
+	res, err := srv.Client().Get(srv.URL)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	defer res.Body.Close()
+	if ct := res.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Fatalf("got content type %q, want %q", ct, "text/plain; version=0.0.4; charset=utf-8")
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	for _, line := range []string{
+		"# TYPE then_stage_calls_total counter",
+		`then_stage_calls_total{stage="say \"hi\""} 2`,
+		`then_stage_successes_total{stage="say \"hi\""} 1`,
+		`then_stage_failures_total{stage="say \"hi\"",class="bad"} 1`,
+		"# TYPE then_stage_duration_seconds histogram",
+		`then_stage_duration_seconds_bucket{stage="say \"hi\"",le="0.25"} 0`,
+		`then_stage_duration_seconds_bucket{stage="say \"hi\"",le="1"} 2`,
+		`then_stage_duration_seconds_bucket{stage="say \"hi\"",le="+Inf"} 2`,
+		`then_stage_duration_seconds_sum{stage="say \"hi\""} 1`,
+		`then_stage_duration_seconds_count{stage="say \"hi\""} 2`,
+	} {
+		if !strings.Contains(string(body), line+"\n") {
+			t.Fatalf("got\n%s\nwant it to contain %q", body, line)
+		}
+	}
+}
+
+func TestVar(t *testing.T) {
+	reg := metrics.NewRegistry(metrics.Config{})
+	metrics.Stage(reg, "id", then.Lift(func(x int) int { return x }))(1)
+
+	var got map[string]metrics.StageSnapshot
+	if err := json.Unmarshal([]byte(reg.Var().String()), &got); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if s := got["id"]; s.Calls != 1 || s.Successes != 1 || len(s.Buckets) != len(metrics.DefaultBuckets)+1 {
+		t.Fatalf("got %+v, want 1 successful call with %d buckets", got, len(metrics.DefaultBuckets)+1)
+	}
+}
+
+func BenchmarkStage(b *testing.B) {
+	reg := metrics.NewRegistry(metrics.Config{})
+	f := metrics.Stage(reg, "id", then.Lift(func(x int) int { return x }))
+	errF := errors.New("f")
+	g := metrics.Stage(reg, "fail", func(int) (int, error) { return 0, errF })
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			if i%2 == 0 {
+				f(i)
+			} else {
+				g(i)
+			}
+		}
+	})
+}
@@ -0,0 +1,138 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Config lists the stages of a pipeline in order.
+type Config struct {
+	Stages []StageConfig `json:"stages"`
+}
+
+// StageConfig refers to a registered stage. In JSON, it can also be given as
+// just the name of the stage.
+type StageConfig struct {
+	Name     string `json:"name"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+func (sc *StageConfig) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*sc = StageConfig{}
+		return json.Unmarshal(data, &sc.Name)
+	}
+	type plain StageConfig
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode((*plain)(sc))
+}
+
+// ParseConfig parses a `Config` from JSON or from a small subset of YAML:
+//
+//	# Comments and blank lines are ignored.
+//	stages:
+//	  - parse
+//	  - name: enrich
+//	    disabled: true
+//	  - store
+//
+// Input starting with "{" is treated as JSON.
+func ParseConfig(data []byte) (Config, error) {
+	var (
+		cfg Config
+		err error
+	)
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&cfg)
+	} else {
+		cfg, err = parseYAML(string(data))
+	}
+	if err != nil {
+		return Config{}, err
+	}
+	for i, sc := range cfg.Stages {
+		if sc.Name == "" {
+			return Config{}, fmt.Errorf("stage %d has no name", i)
+		}
+	}
+	return cfg, nil
+}
+
+func parseYAML(data string) (Config, error) {
+	var (
+		cfg      Config
+		inStages bool
+		cur      *StageConfig
+	)
+	for i, line := range strings.Split(data, "\n") {
+		fail := func(format string, args ...any) (Config, error) {
+			return Config{}, fmt.Errorf("line %d: %s", i+1, fmt.Sprintf(format, args...))
+		}
+		if j := strings.Index(line, " #"); j >= 0 {
+			line = line[:j]
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			if trimmed != "stages:" {
+				return fail(`expected "stages:", got %q`, trimmed)
+			}
+			inStages = true
+			continue
+		}
+		if !inStages {
+			return fail(`expected "stages:" first`)
+		}
+		item, isItem := strings.CutPrefix(trimmed, "- ")
+		if isItem {
+			cfg.Stages = append(cfg.Stages, StageConfig{})
+			cur = &cfg.Stages[len(cfg.Stages)-1]
+			if !strings.Contains(item, ":") {
+				cur.Name = unquote(item)
+				continue
+			}
+		} else if cur == nil {
+			return fail(`expected "- ", got %q`, trimmed)
+		}
+		key, value, ok := strings.Cut(item, ":")
+		if !ok {
+			return fail(`expected "key: value", got %q`, item)
+		}
+		if err := set(cur, strings.TrimSpace(key), unquote(strings.TrimSpace(value))); err != nil {
+			return fail("%v", err)
+		}
+	}
+	return cfg, nil
+}
+
+func set(sc *StageConfig, key, value string) error {
+	switch key {
+	case "name":
+		sc.Name = value
+	case "disabled", "enabled":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		sc.Disabled = b == (key == "disabled")
+	default:
+		return errors.New("unknown key " + strconv.Quote(key))
+	}
+	return nil
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
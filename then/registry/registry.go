@@ -0,0 +1,147 @@
+// Package registry assembles pipelines of result functions from
+// configuration.
+//
+// Stages are registered under a name together with their input and output
+// types. A `Config` lists the names of the stages to run in order, so stages
+// can be reordered or disabled without recompiling. Adjacent stages are type
+// checked when the pipeline is loaded.
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/kdungs/go-result/then"
+)
+
+// Stage is a registered stage.
+type Stage struct {
+	Name string
+	In   reflect.Type
+	Out  reflect.Type
+	call func(any) (any, error)
+}
+
+// Registry holds stages by name. It is safe for concurrent use.
+type Registry struct {
+	mu     sync.RWMutex
+	stages map[string]Stage
+}
+
+// New returns an empty `Registry`.
+func New() *Registry {
+	return &Registry{stages: make(map[string]Stage)}
+}
+
+// Register adds `f` to `r` under `name`. It returns an error if the name is
+// already taken.
+func Register[A, B any](r *Registry, name string, f then.FN[A, B]) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.stages[name]; ok {
+		return fmt.Errorf("stage %q already registered", name)
+	}
+	r.stages[name] = Stage{
+		Name: name,
+		In:   reflect.TypeFor[A](),
+		Out:  reflect.TypeFor[B](),
+		call: func(v any) (any, error) {
+			// The assertion only fails for nil interface values, for which
+			// the zero value is correct.
+			a, _ := v.(A)
+			return f(a)
+		},
+	}
+	return nil
+}
+
+// Lookup returns the stage registered under `name`.
+func (r *Registry) Lookup(name string) (Stage, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.stages[name]
+	return s, ok
+}
+
+// Stages returns all registered stages ordered by name.
+func (r *Registry) Stages() []Stage {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	stages := make([]Stage, 0, len(r.stages))
+	for _, s := range r.stages {
+		stages = append(stages, s)
+	}
+	sort.Slice(stages, func(i, j int) bool { return stages[i].Name < stages[j].Name })
+	return stages
+}
+
+// ErrUnknownStage is returned by `Load` for stages that are not registered.
+var ErrUnknownStage = errors.New("unknown stage")
+
+// TypeError is returned by `Load` when the output of a stage cannot be passed
+// to the next one. `From` or `To` are empty for the pipeline's input and
+// output, respectively.
+type TypeError struct {
+	From, To string
+	Out, In  reflect.Type
+}
+
+func (e *TypeError) Error() string {
+	switch {
+	case e.From == "" && e.To == "":
+		return fmt.Sprintf("pipeline input %v does not match pipeline output %v", e.Out, e.In)
+	case e.From == "":
+		return fmt.Sprintf("pipeline input is %v but %q expects %v", e.Out, e.To, e.In)
+	case e.To == "":
+		return fmt.Sprintf("stage %q outputs %v but pipeline output is %v", e.From, e.Out, e.In)
+	}
+	return fmt.Sprintf("stage %q outputs %v but %q expects %v", e.From, e.Out, e.To, e.In)
+}
+
+// Load builds a pipeline from A to B out of the enabled stages listed in
+// `cfg`. All unknown stages and type mismatches are reported at once.
+func Load[A, B any](r *Registry, cfg Config) (then.FN[A, B], error) {
+	var (
+		errs  []error
+		calls []func(any) (any, error)
+		from  string
+		out   = reflect.TypeFor[A]()
+	)
+	for _, sc := range cfg.Stages {
+		if sc.Disabled {
+			continue
+		}
+		s, ok := r.Lookup(sc.Name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w %q", ErrUnknownStage, sc.Name))
+			// Skip type checks up to the next known stage.
+			out = nil
+			continue
+		}
+		if out != nil && !out.AssignableTo(s.In) {
+			errs = append(errs, &TypeError{From: from, To: s.Name, Out: out, In: s.In})
+		}
+		calls = append(calls, s.call)
+		from, out = s.Name, s.Out
+	}
+	if in := reflect.TypeFor[B](); out != nil && !out.AssignableTo(in) {
+		errs = append(errs, &TypeError{From: from, Out: out, In: in})
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return func(a A) (B, error) {
+		var v any = a
+		for _, call := range calls {
+			var err error
+			if v, err = call(v); err != nil {
+				return *new(B), err
+			}
+		}
+		b, _ := v.(B)
+		return b, nil
+	}, nil
+}
@@ -0,0 +1,231 @@
+package registry_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kdungs/go-result/then"
+	"github.com/kdungs/go-result/then/registry"
+)
+
+type (
+	Doc    struct{ Text string }
+	Record struct {
+		Text   string
+		Length int
+	}
+)
+
+func newRegistry(t *testing.T) *registry.Registry {
+	t.Helper()
+	r := registry.New()
+	for _, err := range []error{
+		registry.Register(r, "parse", then.Lift(func(s string) Doc { return Doc{Text: s} })),
+		registry.Register(r, "upper", then.Lift(func(d Doc) Doc { return Doc{Text: strings.ToUpper(d.Text)} })),
+		registry.Register(r, "enrich", then.Lift(func(d Doc) Record { return Record{Text: d.Text, Length: len(d.Text)} })),
+		registry.Register(r, "validate", func(rec Record) (Record, error) {
+			if rec.Length == 0 {
+				return Record{}, errors.New("empty")
+			}
+			return rec, nil
+		}),
+	} {
+		if err != nil {
+			t.Fatalf("got %v, want nil", err)
+		}
+	}
+	return r
+}
+
+func TestRegister(t *testing.T) {
+	r := newRegistry(t)
+	if err := registry.Register(r, "parse", then.Lift(strings.TrimSpace)); err == nil {
+		t.Fatalf("got nil, want error for duplicate name")
+	}
+	var names []string
+	for _, s := range r.Stages() {
+		names = append(names, s.Name)
+	}
+	if want := []string{"enrich", "parse", "upper", "validate"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("got %v, want %v", names, want)
+	}
+	s, ok := r.Lookup("enrich")
+	if !ok || s.In != reflect.TypeFor[Doc]() || s.Out != reflect.TypeFor[Record]() {
+		t.Fatalf("got (%+v, %v), want enrich from Doc to Record", s, ok)
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	parsed := registry.Config{Stages: []registry.StageConfig{
+		{Name: "parse"},
+		{Name: "upper", Disabled: true},
+		{Name: "enrich"},
+	}}
+	cases := []struct {
+		name        string
+		data        string
+		expectedErr bool
+		expectedCfg registry.Config
+	}{
+		{
+			name:        "json",
+			data:        `{"stages": ["parse", {"name": "upper", "disabled": true}, {"name": "enrich"}]}`,
+			expectedCfg: parsed,
+		},
+		{
+			name: "yaml",
+			data: `
+# The default pipeline.
+stages:
+  - parse
+  - name: upper # shouting is off for now
+    disabled: true
+  - name: "enrich"
+`,
+			expectedCfg: parsed,
+		},
+		{
+			name:        "yaml enabled",
+			data:        "stages:\n\t- parse\n\t- name: upper\n\t  enabled: false\n\t- 'enrich'\n",
+			expectedCfg: parsed,
+		},
+		{name: "json unknown field", data: `{"stages": [{"name": "parse", "color": "red"}]}`, expectedErr: true},
+		{name: "json no name", data: `{"stages": [{"disabled": true}]}`, expectedErr: true},
+		{name: "yaml wrong key", data: "pipeline:\n  - parse\n", expectedErr: true},
+		{name: "yaml no stages", data: "  - parse\n", expectedErr: true},
+		{name: "yaml no item", data: "stages:\n  name: parse\n", expectedErr: true},
+		{name: "yaml bad bool", data: "stages:\n  - name: parse\n    disabled: maybe\n", expectedErr: true},
+		{name: "yaml unknown key", data: "stages:\n  - name: parse\n    color: red\n", expectedErr: true},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := registry.ParseConfig([]byte(tc.data))
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("got %v, want error: %v", err, tc.expectedErr)
+			}
+			if !tc.expectedErr && !reflect.DeepEqual(cfg, tc.expectedCfg) {
+				t.Fatalf("got %+v, want %+v", cfg, tc.expectedCfg)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	r := newRegistry(t)
+	cases := []struct {
+		name        string
+		config      string
+		in          string
+		expectedErr error
+		expectedVal Record
+	}{
+		{
+			name:        "all stages",
+			config:      "stages:\n  - parse\n  - upper\n  - enrich\n  - validate\n",
+			in:          "hi",
+			expectedVal: Record{Text: "HI", Length: 2},
+		},
+		{
+			name:        "disabled stage",
+			config:      "stages:\n  - parse\n  - name: upper\n    disabled: true\n  - enrich\n  - validate\n",
+			in:          "hi",
+			expectedVal: Record{Text: "hi", Length: 2},
+		},
+		{
+			name:        "stage error",
+			config:      "stages:\n  - parse\n  - enrich\n  - validate\n",
+			in:          "",
+			expectedErr: errors.New("empty"),
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := registry.ParseConfig([]byte(tc.config))
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			f, err := registry.Load[string, Record](r, cfg)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			v, err := f(tc.in)
+			if tc.expectedErr != nil {
+				if err == nil || err.Error() != tc.expectedErr.Error() {
+					t.Fatalf("got %v, want %v", err, tc.expectedErr)
+				}
+				return
+			}
+			if err != nil || v != tc.expectedVal {
+				t.Fatalf("got (%+v, %v), want (%+v, nil)", v, err, tc.expectedVal)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	r := newRegistry(t)
+	cases := []struct {
+		name     string
+		stages   []string
+		expected []string
+	}{
+		{
+			name:     "adjacent stages",
+			stages:   []string{"parse", "validate"},
+			expected: []string{`stage "parse" outputs registry_test.Doc but "validate" expects registry_test.Record`},
+		},
+		{
+			name:   "input and output",
+			stages: []string{"enrich", "upper"},
+			expected: []string{
+				`pipeline input is string but "enrich" expects registry_test.Doc`,
+				`stage "enrich" outputs registry_test.Record but "upper" expects registry_test.Doc`,
+				`stage "upper" outputs registry_test.Doc but pipeline output is registry_test.Record`,
+			},
+		},
+		{
+			name:   "unknown stages",
+			stages: []string{"parse", "shout", "enrich", "store"},
+			expected: []string{
+				`unknown stage "shout"`,
+				`unknown stage "store"`,
+			},
+		},
+		{
+			name:     "empty",
+			expected: []string{"pipeline input string does not match pipeline output registry_test.Record"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg registry.Config
+			for _, s := range tc.stages {
+				cfg.Stages = append(cfg.Stages, registry.StageConfig{Name: s})
+			}
+			_, err := registry.Load[string, Record](r, cfg)
+			if err == nil {
+				t.Fatalf("got nil, want %q", tc.expected)
+			}
+			if got := strings.Split(err.Error(), "\n"); !reflect.DeepEqual(got, tc.expected) {
+				t.Fatalf("got %q, want %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestLoadErrorTypes(t *testing.T) {
+	_, err := registry.Load[string, Record](newRegistry(t), registry.Config{Stages: []registry.StageConfig{{Name: "parse"}}})
+	var terr *registry.TypeError
+	if !errors.As(err, &terr) || terr.From != "parse" || terr.To != "" {
+		t.Fatalf("got %v, want *TypeError from parse", err)
+	}
+	_, err = registry.Load[string, Record](newRegistry(t), registry.Config{Stages: []registry.StageConfig{{Name: "nope"}}})
+	if !errors.Is(err, registry.ErrUnknownStage) {
+		t.Fatalf("got %v, want %v", err, registry.ErrUnknownStage)
+	}
+}
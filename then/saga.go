@@ -0,0 +1,94 @@
+package then
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Saga is a sequence of steps from A to B where every step may come with a
+// compensating action that undoes its side effects. Build one with `NewSaga`
+// and `AddStep`, then run it via `FN`.
+type Saga[A, B any] struct {
+	steps int
+	run   func(a A, undo *[]func() error) (B, error)
+}
+
+// NewSaga starts a saga with the step `f`. If a later step fails,
+// `compensate` is called with the result of `f`. `compensate` may be nil for
+// steps without side effects.
+func NewSaga[A, B any](f FN[A, B], compensate FE[B]) Saga[A, B] {
+	return AddStep(Saga[A, A]{run: func(a A, _ *[]func() error) (A, error) {
+		return a, nil
+	}}, f, compensate)
+}
+
+// AddStep appends the step `f` with its compensating action to `s`. See
+// `NewSaga`.
+func AddStep[A, B, C any](s Saga[A, B], f FN[B, C], compensate FE[C]) Saga[A, C] {
+	step := s.steps
+	return Saga[A, C]{
+		steps: s.steps + 1,
+		run: func(a A, undo *[]func() error) (C, error) {
+			b, err := s.run(a, undo)
+			if err != nil {
+				return *new(C), err
+			}
+			c, err := f(b)
+			if err != nil {
+				return *new(C), &SagaError{Step: step, Err: err}
+			}
+			if compensate != nil {
+				*undo = append(*undo, func() error { return compensate(c) })
+			}
+			return c, nil
+		},
+	}
+}
+
+// FN turns the saga into a result function. Steps are executed in order.
+// When a step fails, the compensating actions of all previous steps are run
+// in reverse order and a `*SagaError` is returned.
+func (s Saga[A, B]) FN() FN[A, B] {
+	return func(a A) (B, error) {
+		var undo []func() error
+		b, err := s.run(a, &undo)
+		if err == nil {
+			return b, nil
+		}
+		serr := err.(*SagaError)
+		for i := len(undo) - 1; i >= 0; i-- {
+			if cerr := undo[i](); cerr != nil {
+				serr.Compensations = append(serr.Compensations, cerr)
+			}
+		}
+		return *new(B), serr
+	}
+}
+
+// SagaError reports the failure of a saga step together with the failures of
+// any compensating actions. Both can be inspected with `errors.Is` and
+// `errors.As`.
+type SagaError struct {
+	// Step is the index of the failed step.
+	Step int
+	// Err is the error returned by the failed step.
+	Err error
+	// Compensations holds the errors returned by compensating actions.
+	Compensations []error
+}
+
+func (e *SagaError) Error() string {
+	msg := fmt.Sprintf("saga step %d failed: %v", e.Step, e.Err)
+	if len(e.Compensations) == 0 {
+		return msg
+	}
+	cs := make([]string, len(e.Compensations))
+	for i, c := range e.Compensations {
+		cs[i] = c.Error()
+	}
+	return fmt.Sprintf("%s; compensation failed: %s", msg, strings.Join(cs, "; "))
+}
+
+func (e *SagaError) Unwrap() []error {
+	return append([]error{e.Err}, e.Compensations...)
+}
@@ -0,0 +1,104 @@
+package then_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/kdungs/go-result/then"
+)
+
+func TestSaga(t *testing.T) {
+	errStep := errors.New("step")
+	errComp := errors.New("compensation")
+	cases := []struct {
+		name          string
+		failStep      int
+		failComp      int
+		expectedLog   []string
+		expectedErr   error
+		expectedStep  int
+		expectedComps int
+	}{
+		{
+			name:        "all steps succeed",
+			failStep:    -1,
+			failComp:    -1,
+			expectedLog: []string{"do 0", "do 1", "do 2"},
+		},
+		{
+			name:          "last step fails",
+			failStep:      2,
+			failComp:      -1,
+			expectedLog:   []string{"do 0", "do 1", "undo 1", "undo 0"},
+			expectedErr:   errStep,
+			expectedStep:  2,
+			expectedComps: 0,
+		},
+		{
+			name:          "first step fails",
+			failStep:      0,
+			failComp:      -1,
+			expectedErr:   errStep,
+			expectedStep:  0,
+			expectedComps: 0,
+		},
+		{
+			name:          "compensation fails",
+			failStep:      2,
+			failComp:      1,
+			expectedLog:   []string{"do 0", "do 1", "undo 1", "undo 0"},
+			expectedErr:   errStep,
+			expectedStep:  2,
+			expectedComps: 1,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var log []string
+			step := func(i int) then.FN[int, int] {
+				return func(x int) (int, error) {
+					if i == tc.failStep {
+						return 0, errStep
+					}
+					log = append(log, fmt.Sprintf("do %d", x))
+					return x + 1, nil
+				}
+			}
+			compensate := func(x int) error {
+				log = append(log, fmt.Sprintf("undo %d", x-1))
+				if x-1 == tc.failComp {
+					return errComp
+				}
+				return nil
+			}
+			s := then.AddStep(
+				then.AddStep(then.NewSaga(step(0), compensate), step(1), compensate),
+				step(2),
+				compensate,
+			)
+			v, err := s.FN()(0)
+			if !reflect.DeepEqual(log, tc.expectedLog) {
+				t.Fatalf("got %v, want %v", log, tc.expectedLog)
+			}
+			if tc.expectedErr == nil {
+				if err != nil || v != 3 {
+					t.Fatalf("got (%d, %v), want (3, nil)", v, err)
+				}
+				return
+			}
+			var serr *then.SagaError
+			if !errors.As(err, &serr) || !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("got %v, want *SagaError wrapping %v", err, tc.expectedErr)
+			}
+			if serr.Step != tc.expectedStep || len(serr.Compensations) != tc.expectedComps {
+				t.Fatalf("got step %d with %d failed compensations, want step %d with %d", serr.Step, len(serr.Compensations), tc.expectedStep, tc.expectedComps)
+			}
+			if tc.expectedComps > 0 && !errors.Is(err, errComp) {
+				t.Fatalf("got %v, want it to contain %v", err, errComp)
+			}
+		})
+	}
+}
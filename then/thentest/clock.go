@@ -0,0 +1,75 @@
+package thentest
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a manually advanced `then.Clock`. Pass it to stages via
+// `then.WithClock` or their configuration.
+type Clock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []waiter
+}
+
+type waiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewClock returns a `Clock` set to an arbitrary but fixed point in time.
+func NewClock() *Clock {
+	return &Clock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+// Now returns the current time of `c`.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that receives the time once `c` has been advanced by
+// at least `d`.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, waiter{c.now.Add(d), ch})
+	return ch
+}
+
+// Advance moves the clock forward by `d`, firing all timers that are due.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = pending
+}
+
+// BlockUntil waits until at least `n` timers are pending. Timers whose
+// channel is no longer read from count until they fire.
+func (c *Clock) BlockUntil(n int) {
+	for {
+		c.mu.Lock()
+		l := len(c.waiters)
+		c.mu.Unlock()
+		if l >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
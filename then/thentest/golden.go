@@ -0,0 +1,151 @@
+package thentest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/kdungs/go-result/then"
+)
+
+var updateGolden = flag.Bool("golden.update", false, "update the golden files of thentest.Golden")
+
+// StageRecord is the input, output and error of a single call of a named
+// stage, as stored in golden files.
+type StageRecord struct {
+	Stage  string          `json:"stage"`
+	Input  json.RawMessage `json:"input"`
+	Output json.RawMessage `json:"output,omitempty"`
+	Err    string          `json:"error,omitempty"`
+}
+
+// Golden records the calls of stages wrapped with `Record` and compares them
+// to a golden file once the test is done, reporting the first stage that
+// diverged. When `Update` is set, which it is if the test is run with
+// `-golden.update`, the golden file is written instead.
+//
+// Inputs and outputs are compared by their JSON encoding. Stages must be
+// called sequentially so that their order is deterministic.
+type Golden struct {
+	// Update determines whether the golden file is written instead of
+	// compared.
+	Update bool
+
+	t       testing.TB
+	path    string
+	mu      sync.Mutex
+	records []StageRecord
+}
+
+// NewGolden returns a `Golden` for the file at `path`, usually in `testdata`.
+func NewGolden(t testing.TB, path string) *Golden {
+	g := &Golden{Update: *updateGolden, t: t, path: path}
+	t.Cleanup(g.finish)
+	return g
+}
+
+// Record wraps `f` so that its calls are recorded as stage `name` in `g`.
+func Record[A, B any](g *Golden, name string, f then.FN[A, B]) then.FN[A, B] {
+	return func(a A) (B, error) {
+		b, err := f(a)
+		rec := StageRecord{Stage: name, Input: g.marshal(a)}
+		if err != nil {
+			rec.Err = err.Error()
+		} else {
+			rec.Output = g.marshal(b)
+		}
+		g.mu.Lock()
+		g.records = append(g.records, rec)
+		g.mu.Unlock()
+		return b, err
+	}
+}
+
+func (g *Golden) marshal(v any) json.RawMessage {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		g.t.Errorf("cannot record %T: %v", v, err)
+	}
+	return bs
+}
+
+// Records returns the calls recorded so far.
+func (g *Golden) Records() []StageRecord {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]StageRecord(nil), g.records...)
+}
+
+func (g *Golden) finish() {
+	g.t.Helper()
+	got := g.Records()
+	if g.Update {
+		bs, err := json.MarshalIndent(got, "", "  ")
+		if err == nil {
+			err = os.MkdirAll(filepath.Dir(g.path), 0o755)
+		}
+		if err == nil {
+			err = os.WriteFile(g.path, append(bs, '\n'), 0o644)
+		}
+		if err != nil {
+			g.t.Errorf("cannot update golden file: %v", err)
+		}
+		return
+	}
+	bs, err := os.ReadFile(g.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		g.t.Errorf("golden file %s does not exist; run with -golden.update to create it", g.path)
+		return
+	}
+	var want []StageRecord
+	if err == nil {
+		err = json.Unmarshal(bs, &want)
+	}
+	if err != nil {
+		g.t.Errorf("cannot read golden file: %v", err)
+		return
+	}
+	if msg := divergence(got, want); msg != "" {
+		g.t.Errorf("pipeline diverged from %s: %s", g.path, msg)
+	}
+}
+
+// divergence describes the first difference between `got` and `want` or
+// returns the empty string if there is none.
+func divergence(got, want []StageRecord) string {
+	for i := 0; i < len(got) && i < len(want); i++ {
+		g, w := got[i], want[i]
+		switch {
+		case g.Stage != w.Stage:
+			return fmt.Sprintf("call #%d: got stage %q, want %q", i, g.Stage, w.Stage)
+		case !jsonEqual(g.Input, w.Input):
+			return fmt.Sprintf("call #%d: stage %q got input %s, want %s", i, g.Stage, g.Input, w.Input)
+		case g.Err != w.Err:
+			return fmt.Sprintf("call #%d: stage %q got error %q, want %q", i, g.Stage, g.Err, w.Err)
+		case !jsonEqual(g.Output, w.Output):
+			return fmt.Sprintf("call #%d: stage %q got output %s, want %s", i, g.Stage, g.Output, w.Output)
+		}
+	}
+	if len(got) > len(want) {
+		return fmt.Sprintf("call #%d: got unexpected call of stage %q", len(want), got[len(want)].Stage)
+	}
+	if len(got) < len(want) {
+		return fmt.Sprintf("call #%d: got no call, want stage %q", len(got), want[len(got)].Stage)
+	}
+	return ""
+}
+
+func jsonEqual(a, b json.RawMessage) bool {
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return bytes.Equal(a, b)
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
@@ -0,0 +1,121 @@
+package thentest_test
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kdungs/go-result/then"
+	"github.com/kdungs/go-result/then/thentest"
+)
+
+// fakeT collects errors and cleanups instead of reporting them.
+type fakeT struct {
+	testing.TB
+	errs     []string
+	cleanups []func()
+}
+
+func (t *fakeT) Helper()          {}
+func (t *fakeT) Cleanup(f func()) { t.cleanups = append(t.cleanups, f) }
+func (t *fakeT) Errorf(format string, args ...any) {
+	t.errs = append(t.errs, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeT) finish() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+type doc struct {
+	Words []string `json:"words"`
+}
+
+func runPipeline(g *thentest.Golden, scale, limit int, in string) {
+	split := thentest.Record(g, "split", then.Lift(func(s string) doc {
+		return doc{strings.Fields(s)}
+	}))
+	count := thentest.Record(g, "count", then.Lift(func(d doc) int {
+		return scale * len(d.Words)
+	}))
+	check := thentest.Record(g, "check", func(n int) (string, error) {
+		if n > limit {
+			return "", errors.New("too many words")
+		}
+		return strconv.Itoa(n), nil
+	})
+	then.Chain(then.Chain(split, count), check)(in)
+}
+
+func TestGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "pipeline.golden.json")
+	record := func(update bool, scale, limit int, in string) []string {
+		ft := &fakeT{TB: t}
+		g := thentest.NewGolden(ft, path)
+		g.Update = update
+		runPipeline(g, scale, limit, in)
+		ft.finish()
+		return ft.errs
+	}
+
+	if errs := record(false, 1, 10, "a b c"); len(errs) != 1 || !strings.Contains(errs[0], "-golden.update") {
+		t.Fatalf("got %q, want missing golden file", errs)
+	}
+	if errs := record(true, 1, 10, "a b c"); len(errs) != 0 {
+		t.Fatalf("got %q, want no errors", errs)
+	}
+	cases := []struct {
+		name     string
+		scale    int
+		limit    int
+		in       string
+		expected string
+	}{
+		{
+			name:  "same",
+			scale: 1,
+			limit: 10,
+			in:    "a b c",
+		},
+		{
+			name:     "input",
+			scale:    1,
+			limit:    10,
+			in:       "a b",
+			expected: `call #0: stage "split" got input "a b", want "a b c"`,
+		},
+		{
+			name:     "output",
+			scale:    2,
+			limit:    10,
+			in:       "a b c",
+			expected: `call #1: stage "count" got output 6, want 3`,
+		},
+		{
+			name:     "error",
+			scale:    1,
+			limit:    2,
+			in:       "a b c",
+			expected: `call #2: stage "check" got error "too many words", want ""`,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := record(false, tc.scale, tc.limit, tc.in)
+			if tc.expected == "" {
+				if len(errs) != 0 {
+					t.Fatalf("got %q, want no errors", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.HasSuffix(errs[0], tc.expected) {
+				t.Fatalf("got %q, want %q", errs, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Package thentest provides fakes for testing pipelines built with package
+// then stage by stage.
+package thentest
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/kdungs/go-result/then"
+)
+
+// ErrInjected is the error returned by stages created with `FailOn`.
+var ErrInjected = errors.New("thentest: injected failure")
+
+// Stub returns a result function that ignores its input and always returns
+// `b` and `err`.
+func Stub[A, B any](b B, err error) then.FN[A, B] {
+	return func(A) (B, error) {
+		return b, err
+	}
+}
+
+// FailOn returns a result function that behaves like `f` except for the
+// `n`-th call (counting from 1), which fails with `ErrInjected`.
+func FailOn[A, B any](f then.FN[A, B], n int) then.FN[A, B] {
+	var mu sync.Mutex
+	calls := 0
+	return func(a A) (B, error) {
+		mu.Lock()
+		calls++
+		fail := calls == n
+		mu.Unlock()
+		if fail {
+			return *new(B), ErrInjected
+		}
+		return f(a)
+	}
+}
+
+// Recorder records the inputs of every call to a result function. It is safe
+// for concurrent use.
+type Recorder[A, B any] struct {
+	f      then.FN[A, B]
+	mu     sync.Mutex
+	inputs []A
+}
+
+// NewRecorder returns a `Recorder` for `f`.
+func NewRecorder[A, B any](f then.FN[A, B]) *Recorder[A, B] {
+	return &Recorder[A, B]{f: f}
+}
+
+// FN returns the recording result function.
+func (r *Recorder[A, B]) FN() then.FN[A, B] {
+	return func(a A) (B, error) {
+		r.mu.Lock()
+		r.inputs = append(r.inputs, a)
+		r.mu.Unlock()
+		return r.f(a)
+	}
+}
+
+// Calls returns the number of calls so far.
+func (r *Recorder[A, B]) Calls() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.inputs)
+}
+
+// Inputs returns the inputs of all calls so far, in order.
+func (r *Recorder[A, B]) Inputs() []A {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]A(nil), r.inputs...)
+}
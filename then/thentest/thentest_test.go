@@ -0,0 +1,49 @@
+package thentest_test
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/kdungs/go-result/then"
+	"github.com/kdungs/go-result/then/thentest"
+)
+
+func TestStub(t *testing.T) {
+	errS := errors.New("s")
+	pipeline := then.Chain(strconv.Atoi, thentest.Stub[int, string]("", errS))
+	if _, err := pipeline("42"); err != errS {
+		t.Fatalf("got %v, want %v", err, errS)
+	}
+}
+
+func TestRecorderAndFailOn(t *testing.T) {
+	rec := thentest.NewRecorder(thentest.FailOn(then.Lift(func(x int) int { return x * 2 }), 2))
+	pipeline := then.Chain(strconv.Atoi, rec.FN())
+	cases := []struct {
+		in          string
+		expected    int
+		expectedErr error
+	}{
+		{in: "1", expected: 2},
+		{in: "2", expectedErr: thentest.ErrInjected},
+		{in: "x", expectedErr: strconv.ErrSyntax},
+		{in: "3", expected: 6},
+	}
+	for _, tc := range cases {
+		v, err := pipeline(tc.in)
+		if !errors.Is(err, tc.expectedErr) || (tc.expectedErr == nil && err != nil) {
+			t.Fatalf("%s: got %v, want %v", tc.in, err, tc.expectedErr)
+		}
+		if err == nil && v != tc.expected {
+			t.Fatalf("%s: got %d, want %d", tc.in, v, tc.expected)
+		}
+	}
+	if c := rec.Calls(); c != 3 {
+		t.Fatalf("got %d calls, want 3", c)
+	}
+	if in := rec.Inputs(); !reflect.DeepEqual(in, []int{1, 2, 3}) {
+		t.Fatalf("got %v, want [1 2 3]", in)
+	}
+}
@@ -0,0 +1,121 @@
+package then
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TimeoutError is returned by stages that did not finish within the time they
+// were allotted.
+type TimeoutError struct {
+	// Stage is the name of the stage that timed out, if any.
+	Stage string
+	// After is the time the stage was allowed to take.
+	After time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Stage == "" {
+		return fmt.Sprintf("timed out after %v", e.After)
+	}
+	return fmt.Sprintf("stage %q timed out after %v", e.Stage, e.After)
+}
+
+// Is makes `errors.Is(err, context.DeadlineExceeded)` hold for timeouts, so
+// they can be handled like any other missed deadline.
+func (e *TimeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// Timeout limits the time `f` may take to `d`. If `f` does not return in time,
+// the call fails with a `*TimeoutError` and the context passed to `f` is
+// cancelled. `f` runs on its own goroutine which exits as soon as `f` returns;
+// it is never blocked on delivering its (discarded) result.
+func Timeout[A, B any](f FC[A, B], d time.Duration) FC[A, B] {
+	return func(ctx context.Context, a A) (B, error) {
+		return within(ctx, "", d, f, a)
+	}
+}
+
+func within[A, B any](ctx context.Context, stage string, d time.Duration, f FC[A, B], a A) (B, error) {
+	if ctx.Err() != nil {
+		return *new(B), context.Cause(ctx)
+	}
+	if d <= 0 {
+		return *new(B), &TimeoutError{Stage: stage, After: d}
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	type res struct {
+		b   B
+		err error
+	}
+	done := make(chan res, 1)
+	go func() {
+		b, err := f(ctx, a)
+		done <- res{b, err}
+	}()
+	select {
+	case r := <-done:
+		return r.b, r.err
+	case <-ClockFrom(ctx).After(d):
+		err := &TimeoutError{Stage: stage, After: d}
+		cancel(err)
+		return *new(B), err
+	case <-ctx.Done():
+		return *new(B), context.Cause(ctx)
+	}
+}
+
+// Budget splits an overall deadline across the named stages of a pipeline so
+// that slow early stages cannot starve later ones.
+type Budget struct {
+	// Total is the time a whole run of the pipeline may take.
+	Total time.Duration
+	// Shares assigns fixed fractions of `Total` to stages by name. A stage
+	// with a share may take at most that fraction of `Total`; a stage without
+	// one may take all of the remaining time. In both cases, no stage may take
+	// longer than what is left of the run.
+	Shares map[string]float64
+}
+
+type budgetKey struct{ b *Budget }
+
+// Start returns a copy of `ctx` that carries a new run of `b` beginning now.
+// Stages of `b` called with a context without a run treat each call as a
+// run of its own.
+func (b *Budget) Start(ctx context.Context) context.Context {
+	return context.WithValue(ctx, budgetKey{b}, ClockFrom(ctx).Now().Add(b.Total))
+}
+
+func (b *Budget) allowance(ctx context.Context, name string) time.Duration {
+	now := ClockFrom(ctx).Now()
+	deadline, ok := ctx.Value(budgetKey{b}).(time.Time)
+	if !ok {
+		deadline = now.Add(b.Total)
+	}
+	remaining := deadline.Sub(now)
+	if share, ok := b.Shares[name]; ok {
+		if d := time.Duration(share * float64(b.Total)); d < remaining {
+			return d
+		}
+	}
+	return remaining
+}
+
+// Budgeted starts a new run of `b` for every call of `f` and fails with a
+// `*TimeoutError` if the run takes longer than `b.Total`.
+func Budgeted[A, B any](b *Budget, f FC[A, B]) FC[A, B] {
+	return func(ctx context.Context, a A) (B, error) {
+		return within(b.Start(ctx), "", b.Total, f, a)
+	}
+}
+
+// Stage wraps `f` as the stage `name` of `b`. The call fails with a
+// `*TimeoutError` naming the stage if `f` exceeds its allowance.
+func Stage[A, B any](b *Budget, name string, f FC[A, B]) FC[A, B] {
+	return func(ctx context.Context, a A) (B, error) {
+		return within(ctx, name, b.allowance(ctx, name), f, a)
+	}
+}
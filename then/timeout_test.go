@@ -0,0 +1,173 @@
+package then_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kdungs/go-result/then"
+	"github.com/kdungs/go-result/then/thentest"
+)
+
+func TestTimeout(t *testing.T) {
+	errF := errors.New("f")
+	cases := []struct {
+		name        string
+		f           then.FC[int, int]
+		expectedErr error
+		expected    int
+	}{
+		{
+			name:     "value",
+			f:        func(_ context.Context, x int) (int, error) { return x + 1, nil },
+			expected: 43,
+		},
+		{
+			name:        "error",
+			f:           func(_ context.Context, _ int) (int, error) { return 0, errF },
+			expectedErr: errF,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := then.WithClock(context.Background(), thentest.NewClock())
+			v, err := then.Timeout(tc.f, time.Second)(ctx, 42)
+			if err != tc.expectedErr {
+				t.Fatalf("got %v, want %v", err, tc.expectedErr)
+			}
+			if err == nil && v != tc.expected {
+				t.Fatalf("got %d, want %d", v, tc.expected)
+			}
+		})
+	}
+}
+
+func TestTimeoutOverrun(t *testing.T) {
+	clock := thentest.NewClock()
+	ctx := then.WithClock(context.Background(), clock)
+	exited := make(chan struct{})
+	slow := func(ctx context.Context, _ int) (int, error) {
+		defer close(exited)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+	errc := make(chan error)
+	go func() {
+		_, err := then.Timeout(slow, time.Second)(ctx, 42)
+		errc <- err
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	err := <-errc
+	var te *then.TimeoutError
+	if !errors.As(err, &te) || te.After != time.Second {
+		t.Fatalf("got %v, want timeout after 1s", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want it to match context.DeadlineExceeded", err)
+	}
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		t.Fatal("stage goroutine did not exit after timeout")
+	}
+}
+
+func TestTimeoutCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := then.Timeout(then.Ctx(func(x int) (int, error) { return x, nil }), time.Second)(ctx, 42)
+	if err != context.Canceled {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestBudget(t *testing.T) {
+	clock := thentest.NewClock()
+	block := func(ctx context.Context, x int) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+	advance := func(d time.Duration) then.FC[int, int] {
+		return func(_ context.Context, x int) (int, error) {
+			clock.Advance(d)
+			return x, nil
+		}
+	}
+	b := &then.Budget{
+		Total:  10 * time.Second,
+		Shares: map[string]float64{"a": 0.3, "b": 0.5},
+	}
+	cases := []struct {
+		name     string
+		run      func(ctx context.Context) error
+		timers   int
+		advance  time.Duration
+		expected then.TimeoutError
+	}{
+		{
+			name: "fixed share",
+			run: func(ctx context.Context) error {
+				_, err := then.Budgeted(b, then.Stage(b, "a", block))(ctx, 0)
+				return err
+			},
+			timers:   2,
+			advance:  3 * time.Second,
+			expected: then.TimeoutError{Stage: "a", After: 3 * time.Second},
+		},
+		{
+			name: "later stage keeps its share",
+			run: func(ctx context.Context) error {
+				_, err := then.Budgeted(b, then.ChainC(
+					then.Stage(b, "a", advance(2*time.Second)),
+					then.Stage(b, "b", block),
+				))(ctx, 0)
+				return err
+			},
+			timers:   3,
+			advance:  5 * time.Second,
+			expected: then.TimeoutError{Stage: "b", After: 5 * time.Second},
+		},
+		{
+			name: "share is capped by remaining time",
+			run: func(ctx context.Context) error {
+				_, err := then.ChainC(
+					then.Stage(b, "c", advance(9*time.Second)),
+					then.Stage(b, "b", block),
+				)(b.Start(ctx), 0)
+				return err
+			},
+			timers:   2,
+			advance:  time.Second,
+			expected: then.TimeoutError{Stage: "b", After: time.Second},
+		},
+		{
+			name: "remaining time without run",
+			run: func(ctx context.Context) error {
+				_, err := then.Stage(b, "c", block)(ctx, 0)
+				return err
+			},
+			timers:   1,
+			advance:  10 * time.Second,
+			expected: then.TimeoutError{Stage: "c", After: 10 * time.Second},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			clock = thentest.NewClock()
+			ctx := then.WithClock(context.Background(), clock)
+			errc := make(chan error)
+			go func() { errc <- tc.run(ctx) }()
+			clock.BlockUntil(tc.timers)
+			clock.Advance(tc.advance)
+			err := <-errc
+			var te *then.TimeoutError
+			if !errors.As(err, &te) || *te != tc.expected {
+				t.Fatalf("got %v, want %v", err, &tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/kdungs/go-result/then"
+)
+
+// Stage traces the result function `f` as the stage `name`.
+func Stage[A, B any](name string, f then.FN[A, B]) then.FC[A, B] {
+	return StageC(name, then.Ctx(f))
+}
+
+// StageC traces the context-aware result function `f` as the stage `name`.
+// `f` may add attributes to its span with `SetAttr`.
+func StageC[A, B any](name string, f then.FC[A, B]) then.FC[A, B] {
+	return func(ctx context.Context, a A) (B, error) {
+		return run(ctx, name, "stage", func(ctx context.Context) (B, error) {
+			return f(ctx, a)
+		})
+	}
+}
+
+// Chain is `then.Chain` with a span named `name` around both functions.
+func Chain[A, B, C any](name string, f then.FC[A, B], g then.FC[B, C]) then.FC[A, C] {
+	h := then.ChainC(f, g)
+	return func(ctx context.Context, a A) (C, error) {
+		return run(ctx, name, "chain", func(ctx context.Context) (C, error) {
+			return h(ctx, a)
+		})
+	}
+}
+
+// Map is `then.Map` with a span named `name` around both functions.
+func Map[A, B, C any](name string, f then.FC[A, B], g then.F[B, C]) then.FC[A, C] {
+	h := then.ChainC(f, then.Ctx(then.Lift(g)))
+	return func(ctx context.Context, a A) (C, error) {
+		return run(ctx, name, "map", func(ctx context.Context) (C, error) {
+			return h(ctx, a)
+		})
+	}
+}
+
+// Do is `then.Do` with a span named `name` around both functions.
+func Do[A, B any](name string, f then.FC[A, B], g then.FE[B]) func(context.Context, A) error {
+	return func(ctx context.Context, a A) error {
+		_, err := run(ctx, name, "do", func(ctx context.Context) (struct{}, error) {
+			b, err := f(ctx, a)
+			if err != nil {
+				return struct{}{}, err
+			}
+			return struct{}{}, g(b)
+		})
+		return err
+	}
+}
+
+// Zip is `then.Zip` with a span named `name` around all three functions.
+func Zip[A, B, C, D, E any](name string, f then.FC[A, B], g then.FC[C, D], with func(B, D) (E, error)) func(context.Context, A, C) (E, error) {
+	return func(ctx context.Context, a A, c C) (E, error) {
+		return run(ctx, name, "zip", func(ctx context.Context) (E, error) {
+			return then.Zip(then.Bind(ctx, f), then.Bind(ctx, g), with)(a, c)
+		})
+	}
+}
+
+// Merge is `then.Merge` with a span named `name` around all three functions.
+func Merge[A, B, C, D any](name string, f then.FC[A, B], g then.FC[C, D], with func(B, D) error) func(context.Context, A, C) error {
+	return func(ctx context.Context, a A, c C) error {
+		_, err := run(ctx, name, "merge", func(ctx context.Context) (struct{}, error) {
+			return struct{}{}, then.Merge(then.Bind(ctx, f), then.Bind(ctx, g), with)(a, c)
+		})
+		return err
+	}
+}
@@ -0,0 +1,111 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"runtime/trace"
+	"sync"
+)
+
+// Memory is an `Exporter` that keeps all ended spans in memory, e.g. for
+// tests.
+type Memory struct {
+	mu    sync.Mutex
+	spans []*Span
+}
+
+// NewMemory returns an empty `Memory` exporter.
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+// Start implements `Exporter`.
+func (m *Memory) Start(context.Context, *Span) {}
+
+// End implements `Exporter`.
+func (m *Memory) End(s *Span) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.spans = append(m.spans, s)
+}
+
+// Spans returns all spans ended so far in the order they ended.
+func (m *Memory) Spans() []*Span {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*Span(nil), m.spans...)
+}
+
+// JSONLines is an `Exporter` that writes every ended span as a line of JSON.
+type JSONLines struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+// NewJSONLines returns a `JSONLines` exporter writing to `w`, e.g. an
+// `*os.File`.
+func NewJSONLines(w io.Writer) *JSONLines {
+	return &JSONLines{enc: json.NewEncoder(w)}
+}
+
+// Start implements `Exporter`.
+func (j *JSONLines) Start(context.Context, *Span) {}
+
+// End implements `Exporter`.
+func (j *JSONLines) End(s *Span) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if err := j.enc.Encode(s); err != nil && j.err == nil {
+		j.err = err
+	}
+}
+
+// Err returns the first error encountered while writing spans.
+func (j *JSONLines) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
+
+// RuntimeTrace is an `Exporter` that turns spans into regions of package
+// runtime/trace, so they show up in `go tool trace`. Regions are only
+// recorded while tracing is enabled, e.g. with `go test -trace`.
+type RuntimeTrace struct {
+	regions sync.Map // span ID -> *trace.Region
+}
+
+// NewRuntimeTrace returns a `RuntimeTrace` exporter.
+func NewRuntimeTrace() *RuntimeTrace {
+	return &RuntimeTrace{}
+}
+
+// Start implements `Exporter`.
+func (rt *RuntimeTrace) Start(ctx context.Context, s *Span) {
+	rt.regions.Store(s.ID, trace.StartRegion(ctx, s.Kind+" "+s.Name))
+}
+
+// End implements `Exporter`.
+func (rt *RuntimeTrace) End(s *Span) {
+	if r, ok := rt.regions.LoadAndDelete(s.ID); ok {
+		r.(*trace.Region).End()
+	}
+}
+
+// Multi is an `Exporter` that forwards spans to all of its exporters.
+type Multi []Exporter
+
+// Start implements `Exporter`.
+func (m Multi) Start(ctx context.Context, s *Span) {
+	for _, e := range m {
+		e.Start(ctx, s)
+	}
+}
+
+// End implements `Exporter`.
+func (m Multi) End(s *Span) {
+	for _, e := range m {
+		e.End(s)
+	}
+}
@@ -0,0 +1,141 @@
+// Package tracing records spans for the stages of pipelines built with
+// package then.
+//
+// Spans are propagated through the context, so traced pipelines are built
+// from context-aware result functions using the combinators of this package,
+// which mirror those of package then. Tracing is enabled by adding a `Tracer`
+// to the context with `WithTracer`; without one, spans are not recorded.
+//
+//	pipeline := tracing.Chain("load", tracing.Stage("fetch", fetch), tracing.Stage("parse", parse))
+//	ctx := tracing.WithTracer(ctx, tracing.NewTracer(tracing.NewMemory()))
+//	doc, err := pipeline(ctx, url)
+package tracing
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kdungs/go-result/then"
+)
+
+// Span describes a single execution of a stage or a combinator.
+type Span struct {
+	ID     uint64 `json:"id"`
+	Parent uint64 `json:"parent,omitempty"`
+	Name   string `json:"name"`
+	// Kind is "stage" for stages and the name of the combinator otherwise,
+	// e.g. "chain".
+	Kind  string         `json:"kind"`
+	Start time.Time      `json:"start"`
+	End   time.Time      `json:"end"`
+	Err   string         `json:"error,omitempty"`
+	Attrs map[string]any `json:"attrs,omitempty"`
+
+	mu     sync.Mutex
+	tracer *Tracer
+}
+
+// SetAttr sets the attribute `key` of `s` to `value`.
+func (s *Span) SetAttr(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Attrs == nil {
+		s.Attrs = make(map[string]any)
+	}
+	s.Attrs[key] = value
+}
+
+// Duration returns the time between the start and the end of `s`.
+func (s *Span) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// Exporter receives spans from a `Tracer`. It must be safe for concurrent
+// use.
+type Exporter interface {
+	// Start is called when `s` starts, on the goroutine that executes it.
+	Start(ctx context.Context, s *Span)
+	// End is called when `s` ends, on the same goroutine as `Start`. `s` is
+	// not modified afterwards.
+	End(s *Span)
+}
+
+// Tracer creates spans and hands them to an `Exporter`.
+type Tracer struct {
+	exp Exporter
+	ids atomic.Uint64
+}
+
+// NewTracer returns a `Tracer` exporting to `exp`.
+func NewTracer(exp Exporter) *Tracer {
+	return &Tracer{exp: exp}
+}
+
+type (
+	tracerKey struct{}
+	spanKey   struct{}
+)
+
+// WithTracer returns a copy of `ctx` that carries `t`.
+func WithTracer(ctx context.Context, t *Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, t)
+}
+
+// FromContext returns the span carried by `ctx` or nil if there is none.
+func FromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// SetAttr sets an attribute on the span carried by `ctx`, if any.
+func SetAttr(ctx context.Context, key string, value any) {
+	if s := FromContext(ctx); s != nil {
+		s.SetAttr(key, value)
+	}
+}
+
+// Start starts a span as a child of the span carried by `ctx`. It returns nil
+// if `ctx` does not carry a `Tracer`.
+func Start(ctx context.Context, name, kind string) (context.Context, *Span) {
+	t, ok := ctx.Value(tracerKey{}).(*Tracer)
+	if !ok {
+		return ctx, nil
+	}
+	s := &Span{
+		ID:     t.ids.Add(1),
+		Name:   name,
+		Kind:   kind,
+		Start:  then.ClockFrom(ctx).Now(),
+		tracer: t,
+	}
+	if p := FromContext(ctx); p != nil {
+		s.Parent = p.ID
+	}
+	ctx = context.WithValue(ctx, spanKey{}, s)
+	t.exp.Start(ctx, s)
+	return ctx, s
+}
+
+// Finish ends `s` with `err`, which may be nil. It does nothing if `s` is
+// nil, so it can be used with the result of `Start` unconditionally.
+func (s *Span) Finish(ctx context.Context, err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.End = then.ClockFrom(ctx).Now()
+	if err != nil {
+		s.Err = err.Error()
+	}
+	s.mu.Unlock()
+	s.tracer.exp.End(s)
+}
+
+func run[T any](ctx context.Context, name, kind string, f func(context.Context) (T, error)) (T, error) {
+	ctx, s := Start(ctx, name, kind)
+	v, err := f(ctx)
+	s.Finish(ctx, err)
+	return v, err
+}
@@ -0,0 +1,148 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime/trace"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kdungs/go-result/then"
+	"github.com/kdungs/go-result/then/thentest"
+	"github.com/kdungs/go-result/then/tracing"
+)
+
+var errOdd = errors.New("odd")
+
+type span struct {
+	Name, Kind, Parent, Err string
+	Dur                     time.Duration
+}
+
+func flatten(spans []*tracing.Span) []span {
+	names := make(map[uint64]string)
+	for _, s := range spans {
+		names[s.ID] = s.Name
+	}
+	var out []span
+	for _, s := range spans {
+		out = append(out, span{s.Name, s.Kind, names[s.Parent], s.Err, s.Duration()})
+	}
+	return out
+}
+
+func TestTrace(t *testing.T) {
+	clock := thentest.NewClock()
+	mem := tracing.NewMemory()
+	ctx := then.WithClock(tracing.WithTracer(context.Background(), tracing.NewTracer(mem)), clock)
+	sum := tracing.Zip(
+		"sum",
+		tracing.Map("double", tracing.StageC("parse", func(ctx context.Context, s string) (int, error) {
+			clock.Advance(time.Millisecond)
+			tracing.SetAttr(ctx, "input", s)
+			return strconv.Atoi(s)
+		}), func(x int) int { return 2 * x }),
+		tracing.Stage("parse", strconv.Atoi),
+		func(a, b int) (int, error) { return a + b, nil },
+	)
+	check := tracing.Do("check", tracing.Chain("id", tracing.Stage("a", then.Lift(func(x int) int { return x })), tracing.Stage("b", then.Lift(func(x int) int { return x }))), func(x int) error {
+		if x%2 != 0 {
+			return errOdd
+		}
+		return nil
+	})
+	v, err := sum(ctx, "21", "1")
+	if err != nil || v != 43 {
+		t.Fatalf("got (%d, %v), want (43, nil)", v, err)
+	}
+	if err := check(ctx, v); err != errOdd {
+		t.Fatalf("got %v, want %v", err, errOdd)
+	}
+	if _, err := sum(ctx, "x", "1"); err == nil {
+		t.Fatal("got nil, want error")
+	}
+
+	spans := mem.Spans()
+	expected := []span{
+		{Name: "parse", Kind: "stage", Parent: "double", Dur: time.Millisecond},
+		{Name: "double", Kind: "map", Parent: "sum", Dur: time.Millisecond},
+		{Name: "parse", Kind: "stage", Parent: "sum"},
+		{Name: "sum", Kind: "zip", Dur: time.Millisecond},
+		{Name: "a", Kind: "stage", Parent: "id"},
+		{Name: "b", Kind: "stage", Parent: "id"},
+		{Name: "id", Kind: "chain", Parent: "check"},
+		{Name: "check", Kind: "do", Err: "odd"},
+		{Name: "parse", Kind: "stage", Parent: "double", Dur: time.Millisecond, Err: `strconv.Atoi: parsing "x": invalid syntax`},
+		{Name: "double", Kind: "map", Parent: "sum", Dur: time.Millisecond, Err: `strconv.Atoi: parsing "x": invalid syntax`},
+		{Name: "sum", Kind: "zip", Dur: time.Millisecond, Err: `strconv.Atoi: parsing "x": invalid syntax`},
+	}
+	if got := flatten(spans); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("got\n%+v\nwant\n%+v", got, expected)
+	}
+	if attrs := spans[0].Attrs; !reflect.DeepEqual(attrs, map[string]any{"input": "21"}) {
+		t.Fatalf("got %v, want map[input:21]", attrs)
+	}
+}
+
+func TestMergeAndJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	jl := tracing.NewJSONLines(&buf)
+	ctx := tracing.WithTracer(context.Background(), tracing.NewTracer(jl))
+	merge := tracing.Merge("write", tracing.Stage("a", strconv.Atoi), tracing.Stage("b", strconv.Atoi), func(a, b int) error {
+		return fmt.Errorf("%d + %d", a, b)
+	})
+	if err := merge(ctx, "1", "2"); err == nil || err.Error() != "1 + 2" {
+		t.Fatalf("got %v, want 1 + 2", err)
+	}
+	if err := jl.Err(); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	var kinds []string
+	for _, l := range lines {
+		var s tracing.Span
+		if err := json.Unmarshal([]byte(l), &s); err != nil {
+			t.Fatalf("got %v, want nil", err)
+		}
+		kinds = append(kinds, s.Kind+" "+s.Name)
+	}
+	if expected := []string{"stage a", "stage b", "merge write"}; !reflect.DeepEqual(kinds, expected) {
+		t.Fatalf("got %v, want %v", kinds, expected)
+	}
+}
+
+func TestRuntimeTrace(t *testing.T) {
+	if trace.IsEnabled() {
+		t.Skip("tracing is already enabled")
+	}
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Fatal(err)
+	}
+	mem := tracing.NewMemory()
+	ctx := tracing.WithTracer(context.Background(), tracing.NewTracer(tracing.Multi{tracing.NewRuntimeTrace(), mem}))
+	_, err := tracing.Chain("c", tracing.Stage("a", strconv.Atoi), tracing.Stage("b", then.Lift(strconv.Itoa)))(ctx, "42")
+	trace.Stop()
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if n := len(mem.Spans()); n != 3 {
+		t.Fatalf("got %d spans, want 3", n)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("chain c")) {
+		t.Fatal("got trace without region \"chain c\"")
+	}
+}
+
+func TestNoTracer(t *testing.T) {
+	v, err := tracing.Stage("a", strconv.Atoi)(context.Background(), "42")
+	if err != nil || v != 42 {
+		t.Fatalf("got (%d, %v), want (42, nil)", v, err)
+	}
+}
@@ -0,0 +1,81 @@
+package workflow
+
+import (
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Store persists checkpoints. Keys consist of segments separated by "/".
+type Store interface {
+	// Get returns the data stored under `key`. It reports false if there is
+	// none.
+	Get(key string) ([]byte, bool, error)
+	// Put stores `data` under `key`, replacing what was there.
+	Put(key string, data []byte) error
+}
+
+// DirStore is a `Store` that keeps every key in a file below a directory.
+// Writes are atomic: a crash leaves either the old or the new data.
+type DirStore struct {
+	dir string
+}
+
+// NewDirStore returns a `DirStore` rooted at `dir`, creating the directory if
+// necessary.
+func NewDirStore(dir string) (*DirStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DirStore{dir}, nil
+}
+
+func (s *DirStore) path(key string) string {
+	segs := strings.Split(key, "/")
+	for i, seg := range segs {
+		seg = url.PathEscape(seg)
+		if strings.HasPrefix(seg, ".") {
+			seg = "%2E" + seg[1:]
+		}
+		segs[i] = seg
+	}
+	return filepath.Join(append([]string{s.dir}, segs...)...)
+}
+
+func (s *DirStore) Get(key string) ([]byte, bool, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+func (s *DirStore) Put(key string, data []byte) error {
+	p := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(p), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), p)
+}
@@ -0,0 +1,243 @@
+// Package workflow runs pipelines of named steps durably.
+//
+// The output of every step is checkpointed to a `Store`. When a run is
+// repeated after a crash or failure, completed steps are not run again;
+// execution resumes after the last checkpointed step. Runs are identified by
+// an idempotency key derived from their input, so repeating a completed run
+// returns its stored output.
+package workflow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kdungs/go-result/then"
+)
+
+// Workflow is a sequence of named steps from A to B. Build one with `New` and
+// `AddStep`, then run it via `NewRunner`.
+type Workflow[A, B any] struct {
+	name  string
+	steps []string
+	run   func(r *run, a A) (B, error)
+}
+
+// New starts the workflow `name` with the step `f` called `step`.
+func New[A, B any](name, step string, f then.FN[A, B]) Workflow[A, B] {
+	return AddStep(Workflow[A, A]{name: name, run: func(_ *run, a A) (A, error) {
+		return a, nil
+	}}, step, f)
+}
+
+// AddStep appends the step `f` called `name` to `w`. Step names must be unique
+// within a workflow.
+func AddStep[A, B, C any](w Workflow[A, B], name string, f then.FN[B, C]) Workflow[A, C] {
+	if slices.Contains(w.steps, name) {
+		panic(fmt.Sprintf("workflow: duplicate step %q", name))
+	}
+	return Workflow[A, C]{
+		name:  w.name,
+		steps: append(slices.Clip(w.steps), name),
+		run: func(r *run, a A) (C, error) {
+			var c C
+			ok, err := r.load(name, &c)
+			if err != nil || ok {
+				return c, err
+			}
+			b, err := w.run(r, a)
+			if err != nil {
+				return c, err
+			}
+			if c, err = f(b); err != nil {
+				return c, r.fail(name, err)
+			}
+			return c, r.save(name, c)
+		},
+	}
+}
+
+// Steps returns the names of the steps of `w` in order.
+func (w Workflow[A, B]) Steps() []string {
+	return slices.Clone(w.steps)
+}
+
+// Config configures a `Runner`.
+type Config[A any] struct {
+	// Store holds checkpoints and run status.
+	Store Store
+	// Codec serializes step outputs. If nil, `JSON` is used.
+	Codec Codec
+	// Key returns the idempotency key of a run for an input. If nil, a hash
+	// of the input serialized with `JSON` is used, whatever `Codec` is, since
+	// not every codec encodes the same input to the same bytes.
+	Key func(A) string
+	// Clock is used to timestamp status updates. If nil, `then.SystemClock`
+	// is used.
+	Clock then.Clock
+}
+
+// State is the state of a run.
+type State string
+
+const (
+	Running   State = "running"
+	Succeeded State = "succeeded"
+	Failed    State = "failed"
+)
+
+// Status describes a run.
+type Status struct {
+	Workflow string `json:"workflow"`
+	Run      string `json:"run"`
+	State    State  `json:"state"`
+	// Completed lists the checkpointed steps in the order they completed.
+	Completed []string `json:"completed"`
+	// Step and Err describe the step that failed last, if any.
+	Step    string    `json:"step,omitempty"`
+	Err     string    `json:"error,omitempty"`
+	Updated time.Time `json:"updated"`
+}
+
+// Runner runs a workflow, checkpointing to a `Store`. Runs with the same key
+// must not be executed concurrently.
+type Runner[A, B any] struct {
+	w   Workflow[A, B]
+	cfg Config[A]
+}
+
+// NewRunner returns a `Runner` for `w`.
+func NewRunner[A, B any](w Workflow[A, B], cfg Config[A]) *Runner[A, B] {
+	if cfg.Codec == nil {
+		cfg.Codec = JSON
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = then.SystemClock
+	}
+	return &Runner[A, B]{w, cfg}
+}
+
+// Key returns the idempotency key of the run for `a`.
+func (rn *Runner[A, B]) Key(a A) (string, error) {
+	if rn.cfg.Key != nil {
+		return rn.cfg.Key(a), nil
+	}
+	data, err := JSON.Marshal(a)
+	if err != nil {
+		return "", fmt.Errorf("workflow: key: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Run runs the workflow for `a`, skipping steps that were checkpointed by
+// earlier runs with the same key.
+func (rn *Runner[A, B]) Run(a A) (B, error) {
+	key, err := rn.Key(a)
+	if err != nil {
+		return *new(B), err
+	}
+	st, err := rn.Status(key)
+	if err != nil {
+		return *new(B), err
+	}
+	r := &run{codec: rn.cfg.Codec, store: rn.cfg.Store, clock: rn.cfg.Clock, status: st}
+	if st.State != Succeeded {
+		r.status.State, r.status.Step, r.status.Err = Running, "", ""
+		if err := r.put(); err != nil {
+			return *new(B), err
+		}
+	}
+	b, err := rn.w.run(r, a)
+	if err != nil || r.status.State == Succeeded {
+		return b, err
+	}
+	r.status.State = Succeeded
+	return b, r.put()
+}
+
+// FN returns `Run` as a result function.
+func (rn *Runner[A, B]) FN() then.FN[A, B] {
+	return rn.Run
+}
+
+// Status returns the status of the run with key `key`. Runs that never
+// started have an empty `State`.
+func (rn *Runner[A, B]) Status(key string) (Status, error) {
+	st := Status{Workflow: rn.w.name, Run: key}
+	data, ok, err := rn.cfg.Store.Get(statusKey(rn.w.name, key))
+	if err != nil || !ok {
+		return st, err
+	}
+	if err := json.Unmarshal(data, &st); err != nil {
+		return st, fmt.Errorf("workflow: status of run %q: %w", key, err)
+	}
+	return st, nil
+}
+
+func statusKey(workflow, run string) string {
+	return workflow + "/" + run + "/status"
+}
+
+func stepKey(workflow, run, step string) string {
+	return workflow + "/" + run + "/steps/" + step
+}
+
+// run is the state of a single execution of a workflow.
+type run struct {
+	codec  Codec
+	store  Store
+	clock  then.Clock
+	status Status
+}
+
+func (r *run) load(step string, v any) (bool, error) {
+	data, ok, err := r.store.Get(stepKey(r.status.Workflow, r.status.Run, step))
+	if err != nil || !ok {
+		return false, err
+	}
+	if err := r.codec.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("workflow: load step %q: %w", step, err)
+	}
+	return true, nil
+}
+
+func (r *run) save(step string, v any) error {
+	data, err := r.codec.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("workflow: save step %q: %w", step, err)
+	}
+	if err := r.store.Put(stepKey(r.status.Workflow, r.status.Run, step), data); err != nil {
+		return fmt.Errorf("workflow: save step %q: %w", step, err)
+	}
+	if !slices.Contains(r.status.Completed, step) {
+		r.status.Completed = append(r.status.Completed, step)
+	}
+	return r.put()
+}
+
+// fail records that `step` failed with `err` and returns `err`, joined with
+// the error of recording it if that failed as well.
+func (r *run) fail(step string, err error) error {
+	r.status.State, r.status.Step, r.status.Err = Failed, step, err.Error()
+	if perr := r.put(); perr != nil {
+		return errors.Join(err, perr)
+	}
+	return err
+}
+
+func (r *run) put() error {
+	r.status.Updated = r.clock.Now()
+	data, err := json.Marshal(r.status)
+	if err != nil {
+		return err
+	}
+	if err := r.store.Put(statusKey(r.status.Workflow, r.status.Run), data); err != nil {
+		return fmt.Errorf("workflow: status of run %q: %w", r.status.Run, err)
+	}
+	return nil
+}
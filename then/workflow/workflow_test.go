@@ -0,0 +1,226 @@
+package workflow_test
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kdungs/go-result/then"
+	"github.com/kdungs/go-result/then/thentest"
+	"github.com/kdungs/go-result/then/workflow"
+)
+
+type Batch struct {
+	Date  string
+	Lines []string
+}
+
+// counting wraps `f` and counts its calls in `calls[name]`.
+func counting[A, B any](calls map[string]int, name string, f then.FN[A, B]) then.FN[A, B] {
+	return func(a A) (B, error) {
+		calls[name]++
+		return f(a)
+	}
+}
+
+func TestRunner(t *testing.T) {
+	cases := []struct {
+		name  string
+		codec workflow.Codec
+	}{
+		{name: "json", codec: workflow.JSON},
+		{name: "gob", codec: workflow.Gob},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			store, err := workflow.NewDirStore(t.TempDir())
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			calls := map[string]int{}
+			errSum := errors.New("sum")
+			broken := true
+			w := workflow.AddStep(
+				workflow.AddStep(
+					workflow.New("nightly", "split", counting(calls, "split", then.Lift(func(s string) Batch {
+						return Batch{Date: "2026-10-19", Lines: strings.Fields(s)}
+					}))),
+					"parse",
+					counting(calls, "parse", func(b Batch) ([]int, error) {
+						ns := make([]int, len(b.Lines))
+						for i, l := range b.Lines {
+							n, err := strconv.Atoi(l)
+							if err != nil {
+								return nil, err
+							}
+							ns[i] = n
+						}
+						return ns, nil
+					}),
+				),
+				"sum",
+				counting(calls, "sum", func(ns []int) (int, error) {
+					if broken {
+						return 0, errSum
+					}
+					s := 0
+					for _, n := range ns {
+						s += n
+					}
+					return s, nil
+				}),
+			)
+			clock := thentest.NewClock()
+			r := workflow.NewRunner(w, workflow.Config[string]{Store: store, Codec: tc.codec, Clock: clock})
+			key, err := r.Key("1 2 3")
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+
+			if _, err := r.Run("1 2 3"); !errors.Is(err, errSum) {
+				t.Fatalf("got %v, want %v", err, errSum)
+			}
+			st, err := r.Status(key)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			expected := workflow.Status{
+				Workflow:  "nightly",
+				Run:       key,
+				State:     workflow.Failed,
+				Completed: []string{"split", "parse"},
+				Step:      "sum",
+				Err:       "sum",
+				Updated:   clock.Now(),
+			}
+			if !reflect.DeepEqual(st, expected) {
+				t.Fatalf("got %+v, want %+v", st, expected)
+			}
+
+			// After fixing the problem, the run resumes at the failed step.
+			broken = false
+			for range 2 {
+				if got, err := r.Run("1 2 3"); err != nil || got != 6 {
+					t.Fatalf("got (%d, %v), want (6, nil)", got, err)
+				}
+			}
+			if expected := map[string]int{"split": 1, "parse": 1, "sum": 2}; !reflect.DeepEqual(calls, expected) {
+				t.Fatalf("got %v, want %v", calls, expected)
+			}
+			st, err = r.Status(key)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			if st.State != workflow.Succeeded || st.Err != "" || !reflect.DeepEqual(st.Completed, w.Steps()) {
+				t.Fatalf("got %+v, want %v with steps %v", st, workflow.Succeeded, w.Steps())
+			}
+
+			// A different input is a different run.
+			if got, err := r.FN()("4 5"); err != nil || got != 9 {
+				t.Fatalf("got (%d, %v), want (9, nil)", got, err)
+			}
+			if calls["split"] != 2 {
+				t.Fatalf("got %d calls to split, want 2", calls["split"])
+			}
+		})
+	}
+}
+
+func TestRunnerKey(t *testing.T) {
+	store, err := workflow.NewDirStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	calls := map[string]int{}
+	w := workflow.New("upper", "upper", counting(calls, "upper", then.Lift(func(b Batch) string {
+		return strings.ToUpper(strings.Join(b.Lines, " "))
+	})))
+	r := workflow.NewRunner(w, workflow.Config[Batch]{
+		Store: store,
+		Key:   func(b Batch) string { return "../" + b.Date },
+	})
+	// Runs with the same key are the same run, even for different inputs.
+	for _, lines := range [][]string{{"a"}, {"b"}} {
+		if got, err := r.Run(Batch{Date: "2026-10-19", Lines: lines}); err != nil || got != "A" {
+			t.Fatalf("got (%q, %v), want (%q, nil)", got, err, "A")
+		}
+	}
+	if calls["upper"] != 1 {
+		t.Fatalf("got %d calls to upper, want 1", calls["upper"])
+	}
+	if st, err := r.Status("../2026-10-19"); err != nil || st.State != workflow.Succeeded {
+		t.Fatalf("got (%+v, %v), want %v", st, err, workflow.Succeeded)
+	}
+	if st, err := r.Status("unknown"); err != nil || st.State != "" {
+		t.Fatalf("got (%+v, %v), want empty state", st, err)
+	}
+}
+
+func TestDirStore(t *testing.T) {
+	dir := t.TempDir()
+	s, err := workflow.NewDirStore(dir)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if _, ok, err := s.Get("a/b"); ok || err != nil {
+		t.Fatalf("got (%v, %v), want (false, nil)", ok, err)
+	}
+	for _, key := range []string{"a/b", "../x", "a/.."} {
+		if err := s.Put(key, []byte(key)); err != nil {
+			t.Fatalf("got %v, want nil", err)
+		}
+	}
+	if err := s.Put("a/b", []byte("new")); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	cases := []struct {
+		name     string
+		key      string
+		expected string
+	}{
+		{name: "overwritten", key: "a/b", expected: "new"},
+		{name: "parent", key: "../x", expected: "../x"},
+		{name: "dot dot", key: "a/..", expected: "a/.."},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if data, ok, err := s.Get(tc.key); !ok || err != nil || string(data) != tc.expected {
+				t.Fatalf("got (%q, %v, %v), want (%q, true, nil)", data, ok, err, tc.expected)
+			}
+		})
+	}
+	s2, err := workflow.NewDirStore(dir)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if data, ok, err := s2.Get("../x"); !ok || err != nil || string(data) != "../x" {
+		t.Fatalf("got (%q, %v, %v), want (%q, true, nil)", data, ok, err, "../x")
+	}
+}
+
+func TestRunnerDefaultKey(t *testing.T) {
+	store, err := workflow.NewDirStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	w := workflow.New("count", "count", then.Lift(func(m map[string]int) int { return len(m) }))
+	r := workflow.NewRunner(w, workflow.Config[map[string]int]{Store: store, Codec: workflow.Gob})
+	in := map[string]int{}
+	for i := range 20 {
+		in[strconv.Itoa(i)] = i
+	}
+	want, err := r.Key(in)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	// gob encodes maps in random order, so a single retry may pass by chance.
+	for range 10 {
+		if got, err := r.Key(in); err != nil || got != want {
+			t.Fatalf("got (%q, %v), want (%q, nil)", got, err, want)
+		}
+	}
+}